@@ -0,0 +1,7 @@
+data "zonefile_content" "example" {
+  origin = "terraform-provider-zonefile.example."
+  records = [
+    { name = null, type = "A", ttl = 3600, data = "10.100.0.10" },
+    { name = "www", type = "CNAME", ttl = 3600, data = "@" },
+  ]
+}
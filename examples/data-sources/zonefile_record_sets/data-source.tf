@@ -2,3 +2,15 @@ data "zonefile_record_sets" "example" {
   origin  = "terraform-provider-zonefile.example."
   content = file("terraform-provider-zonefile.example.zone")
 }
+
+# Keys like "www/A/IN" stay the same as the zone file changes, so that edits
+# to an RRSet's data update the resource in place.
+resource "aws_route53_record" "example" {
+  for_each = data.zonefile_record_sets.example.rrsets_by_key
+
+  zone_id = aws_route53_zone.example.zone_id
+  name    = each.value.fqdn
+  type    = each.value.type
+  ttl     = each.value.ttl
+  records = each.value.data
+}
@@ -0,0 +1,8 @@
+data "zonefile_soa" "example" {
+  origin  = "terraform-provider-zonefile.example."
+  content = file("terraform-provider-zonefile.example.zone")
+}
+
+output "serial" {
+  value = data.zonefile_soa.example.serial
+}
@@ -0,0 +1,9 @@
+output "zone" {
+  value = provider::zonefile::format(
+    provider::zonefile::records(
+      file("terraform-provider-zonefile.example.zone"),
+      "terraform-provider-zonefile.example.",
+    ),
+    "terraform-provider-zonefile.example.",
+  )
+}
@@ -0,0 +1,7 @@
+locals {
+  verification = provider::zonefile::parse_rr(
+    "_acme-challenge TXT \"abc123\"",
+    "terraform-provider-zonefile.example.",
+    300,
+  )
+}
@@ -0,0 +1,6 @@
+locals {
+  records = provider::zonefile::records(
+    file("terraform-provider-zonefile.example.zone"),
+    "terraform-provider-zonefile.example.",
+  )
+}
@@ -0,0 +1,6 @@
+locals {
+  rrsets = provider::zonefile::rrsets(
+    file("terraform-provider-zonefile.example.zone"),
+    "terraform-provider-zonefile.example.",
+  )
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// annotationMarker introduces annotations in the comment after an RR, which
+// the data sources expose as metadata:
+//
+//	www IN A 192.0.2.1 ; served by the edge @tf proxied=true owner=payments
+//
+// Everything after the marker up to the end of the comment is a list of
+// key=value pairs separated by blanks. Values may be quoted, with the same
+// escapes as a Go string literal.
+const annotationMarker = "@tf"
+
+var annotationKey = regexp.MustCompile(`^[A-Za-z0-9_.:/-]+$`)
+
+// parseAnnotations parses the annotations in the comment text of an RR, as
+// given by [dns.ZoneParser.Comment]. The result is never nil unless the
+// annotations are malformed, in which case parseAnnotations also returns the
+// offending token.
+func parseAnnotations(comment string) (map[string]string, string, error) {
+	metadata := make(map[string]string)
+	if !strings.Contains(comment, annotationMarker) {
+		return metadata, "", nil
+	}
+
+	// Comments on multiple lines of an entry come to us joined with blanks,
+	// each starting with its own semicolon. An annotation list ends with the
+	// comment that it's in.
+	for _, segment := range splitComments(comment) {
+		fields := commentFields(segment)
+		start := -1
+		for i, field := range fields {
+			if field == annotationMarker {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			continue
+		}
+
+		for _, field := range fields[start:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, field, errors.New("annotation must be key=value")
+			}
+			if !annotationKey.MatchString(key) {
+				return nil, field, errors.New("bad annotation key")
+			}
+			if _, ok := metadata[key]; ok {
+				return nil, field, errors.New("duplicate annotation key")
+			}
+			if strings.HasPrefix(value, `"`) {
+				var err error
+				if value, err = strconv.Unquote(value); err != nil {
+					return nil, field, errors.New("bad quoted annotation value")
+				}
+			}
+			metadata[key] = value
+		}
+	}
+	return metadata, "", nil
+}
+
+// splitComments splits comment text into individual comments at each
+// semicolon outside of quotes.
+func splitComments(comment string) []string {
+	var (
+		comments      []string
+		start         int
+		quote, escape bool
+	)
+	for i := 0; i < len(comment); i++ {
+		switch c := comment[i]; {
+		case escape:
+			escape = false
+		case c == '\\':
+			escape = true
+		case c == '"':
+			quote = !quote
+		case c == ';' && !quote:
+			comments = append(comments, comment[start:i])
+			start = i + 1
+		}
+	}
+	return append(comments, comment[start:])
+}
+
+// commentFields splits a comment into its blank-separated fields, keeping
+// blanks within quotes.
+func commentFields(comment string) []string {
+	var (
+		fields        []string
+		field         strings.Builder
+		quote, escape bool
+	)
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+	for i := 0; i < len(comment); i++ {
+		c := comment[i]
+		switch {
+		case escape:
+			escape = false
+		case c == '\\':
+			escape = true
+		case c == '"':
+			quote = !quote
+		case !quote && (c == ' ' || c == '\t' || c == '\r'):
+			flush()
+			continue
+		}
+		field.WriteByte(c)
+	}
+	flush()
+	return fields
+}
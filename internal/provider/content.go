@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+	"github.com/samber/lo"
+)
+
+var _ datasource.DataSource = &ContentDataSource{}
+
+type ContentDataSource struct{}
+
+func NewContentDataSource() datasource.DataSource {
+	return &ContentDataSource{}
+}
+
+func (d *ContentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content"
+}
+
+func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Render a DNS zone file from a list of resource records.",
+		Attributes:  schemaContentModel,
+	}
+}
+
+func (d *ContentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ContentModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := formatContent(data.Records, data.Origin.ValueString(), data.TTL)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Can't render zone file", err.Error()))
+		return
+	}
+
+	data.Content = types.StringValue(content)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// formatContent renders records as a zone file. If ttl is null, the most
+// common TTL among the records becomes the default for the file, or with no
+// records (say, after a filter removed all of them) the file has no default.
+func formatContent(records []ContentRecordModel, origin string, ttl types.Int64) (string, error) {
+	explicitTTL := !ttl.IsNull()
+	if !explicitTTL && len(records) == 0 {
+		return formatZone(nil, origin, nil), nil
+	}
+	if !explicitTTL {
+		ttls := lo.FilterMap(records, func(m ContentRecordModel, _ int) (types.Int64, bool) {
+			return m.TTL, !m.TTL.IsNull()
+		})
+		if len(ttls) == 0 {
+			return "", errors.New("at least one record must specify a TTL if no default TTL is set")
+		}
+		counts := lo.CountValues(ttls)
+		ttl = lo.MaxBy(ttls, func(a, b types.Int64) bool { return counts[a] > counts[b] })
+	}
+
+	// A default TTL from the records is out of range only if one of the
+	// records has that TTL, and the error is clearer if it names the record.
+	defaultTTL, err := ttlValue(ttl.ValueInt64())
+	if err != nil && explicitTTL {
+		return "", fmt.Errorf("default %w", err)
+	}
+
+	var rrs []dns.RR
+	for i, m := range records {
+		rr, err := contentRecordRR(m, origin, defaultTTL)
+		if err != nil {
+			return "", fmt.Errorf("record %d: %w", i, err)
+		}
+		rrs = append(rrs, rr)
+	}
+	return formatZone(rrs, origin, &defaultTTL), nil
+}
+
+func contentRecordRR(m ContentRecordModel, origin string, ttl uint32) (dns.RR, error) {
+	owner := "@"
+	switch {
+	case m.FQDN.ValueString() != "":
+		owner = dns.Fqdn(m.FQDN.ValueString())
+	case m.Name.ValueString() != "":
+		owner = m.Name.ValueString()
+	}
+	if !m.TTL.IsNull() {
+		var err error
+		if ttl, err = ttlValue(m.TTL.ValueInt64()); err != nil {
+			return nil, err
+		}
+	}
+	class := lo.Ternary(m.Class.IsNull(), "IN", m.Class.ValueString())
+	return readRR(
+		fmt.Sprintf("%s %d %s %s %s", owner, ttl, class, m.Type.ValueString(), m.Data.ValueString()),
+		origin, nil)
+}
+
+// ttlValue converts a TTL from Terraform to the unsigned 32-bit TTL of an RR,
+// which can't be negative or wrap around.
+func ttlValue(ttl int64) (uint32, error) {
+	if ttl < 0 || ttl > math.MaxUint32 {
+		return 0, fmt.Errorf("TTL must be between 0 and %d", uint32(math.MaxUint32))
+	}
+	return uint32(ttl), nil
+}
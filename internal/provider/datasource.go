@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+	"github.com/samber/lo"
+)
+
+// configureIncludeRoot returns the include_root from the provider
+// configuration passed to a data source, if any.
+func configureIncludeRoot(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) string {
+	if req.ProviderData == nil {
+		return ""
+	}
+	data, ok := req.ProviderData.(*ZonefileProviderModel)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ZonefileProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return ""
+	}
+	return data.IncludeRoot.ValueString()
+}
+
+// validateZoneSourceConfig ensures that the configuration of a data source
+// that parses a zone file specifies exactly one source for the file.
+func validateZoneSourceConfig(ctx context.Context, config tfsdk.Config, resp *datasource.ValidateConfigResponse) {
+	var content, zonePath types.String
+	var includes types.Map
+	var maxErrors types.Int64
+	resp.Diagnostics.Append(config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(config.GetAttribute(ctx, path.Root("path"), &zonePath)...)
+	resp.Diagnostics.Append(config.GetAttribute(ctx, path.Root("includes"), &includes)...)
+	resp.Diagnostics.Append(config.GetAttribute(ctx, path.Root("max_errors"), &maxErrors)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !maxErrors.IsNull() && !maxErrors.IsUnknown() && maxErrors.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_errors"),
+			"Invalid max_errors",
+			"max_errors must be at least 1.")
+	}
+
+	if content.IsUnknown() || zonePath.IsUnknown() {
+		return
+	}
+	if content.IsNull() == zonePath.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("content"),
+			"Invalid zone file source",
+			`Exactly one of "content" or "path" must be set.`)
+	}
+	if !zonePath.IsNull() && !includes.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("includes"),
+			"Invalid zone file source",
+			`"includes" can't be set along with "path". `+
+				`Set include_root in the provider configuration to resolve $INCLUDE directives relative to "path".`)
+	}
+}
+
+// validateZoneOptionsConfig validates the options that the records and
+// record sets data sources share for reading a zone file.
+func validateZoneOptionsConfig(ctx context.Context, config tfsdk.Config, resp *datasource.ValidateConfigResponse) {
+	validateOneOf(ctx, config, resp, "duplicates", duplicatesError, duplicatesWarn, duplicatesKeep)
+	validateOneOf(ctx, config, resp, "origin_mode", originModeExplicit, originModeDirective, originModeSOA)
+	validateOneOf(ctx, config, resp, "out_of_zone", outOfZoneError, outOfZoneWarn, outOfZoneDrop, outOfZoneAllow)
+	validateOneOf(ctx, config, resp, "missing_trailing_dots", trailingDotsError, trailingDotsWarn, trailingDotsIgnore)
+	validateOneOf(ctx, config, resp, "order", orderFile, orderCanonical, orderNameType)
+}
+
+// zoneConfig holds the attributes that both data sources share for reading a
+// zone file.
+type zoneConfig struct {
+	Content        types.String
+	Path           types.String
+	Origin         types.String
+	Includes       map[string]string
+	MaxErrors      types.Int64
+	OriginMode     types.String
+	NormalizeNames types.Bool
+	Duplicates     types.String
+	OutOfZone      types.String
+	TrailingDots   types.String
+	Order          types.String
+
+	IncludeTypes   []string
+	ExcludeTypes   []string
+	IncludeNames   []string
+	ExcludeNames   []string
+	IncludeClasses []string
+}
+
+// Values of the origin_mode attribute.
+const (
+	originModeExplicit  = "explicit"
+	originModeDirective = "from_directive"
+	originModeSOA       = "from_soa"
+)
+
+// Values of the duplicates attribute.
+const (
+	duplicatesError = "error"
+	duplicatesWarn  = "warn"
+	duplicatesKeep  = "keep"
+)
+
+// Values of the out_of_zone attribute.
+const (
+	outOfZoneError = "error"
+	outOfZoneWarn  = "warn"
+	outOfZoneDrop  = "drop"
+	outOfZoneAllow = "allow"
+)
+
+// Values of the missing_trailing_dots attribute.
+const (
+	trailingDotsError  = "error"
+	trailingDotsWarn   = "warn"
+	trailingDotsIgnore = "ignore"
+)
+
+// readZoneConfig reads the RRs in the zone file that a data source
+// configuration describes, and applies the options that apply to both data
+// sources. It also returns the effective origin for the names of the RRs.
+func readZoneConfig(cfg zoneConfig, includeRoot string) ([]zoneRR, string, diag.Diagnostics) {
+	filter, diags := newRecordFilter(cfg)
+	if diags.HasError() {
+		return nil, "", diags
+	}
+
+	src, err := newZoneSource(cfg.Origin.ValueString(), cfg.Content.ValueString(), cfg.Path.ValueString(), cfg.Includes, includeRoot)
+	if err != nil {
+		diags.AddError("Can't read zone file", err.Error())
+		return nil, "", diags
+	}
+
+	src.MaxErrors = maxErrorsValue(cfg.MaxErrors)
+	z, err := readZone(src)
+	if err != nil {
+		diags.Append(zoneErrorDiagnostics("Invalid zone file", err, zoneSourceAttribute(cfg.Path))...)
+		return nil, "", diags
+	}
+	diags.Append(zoneWarningDiagnostics("Malformed annotation", z.Warnings, zoneSourceAttribute(cfg.Path))...)
+	rrs := z.RRs
+
+	origin := cfg.Origin.ValueString()
+	var derived, missing string
+	switch cfg.OriginMode.ValueString() {
+	case originModeDirective:
+		derived, missing = z.DirectiveOrigin, "$ORIGIN directive"
+	case originModeSOA:
+		derived, missing = z.soaOrigin(), "SOA record"
+	}
+	if derived != "" {
+		origin = derived
+	} else if missing != "" && origin == "" {
+		diags.AddAttributeError(path.Root("origin_mode"),
+			"Can't determine origin",
+			fmt.Sprintf("The zone file has no %s to take the origin from, and \"origin\" is not set.", missing))
+		return nil, "", diags
+	}
+	if origin != "" {
+		origin = dns.Fqdn(origin)
+	}
+
+	if cfg.NormalizeNames.ValueBool() {
+		if origin != "" {
+			origin = dns.CanonicalName(origin)
+		}
+		normalizeNames(rrs)
+	}
+	rrs = filter.apply(rrs, origin)
+
+	if mode := cfg.Duplicates.ValueString(); mode == duplicatesError || mode == duplicatesWarn {
+		var dups []error
+		rrs, dups = dedupeRRs(rrs)
+		if len(dups) > 0 && mode == duplicatesError {
+			diags.Append(zoneErrorDiagnostics("Duplicate record", errors.Join(dups...), zoneSourceAttribute(cfg.Path))...)
+			return nil, "", diags
+		}
+		diags.Append(zoneWarningDiagnostics("Duplicate record removed", dups, zoneSourceAttribute(cfg.Path))...)
+	}
+
+	if mode := cfg.OutOfZone.ValueString(); origin != "" && mode != outOfZoneAllow {
+		inZone, outside := splitOutOfZone(rrs, origin)
+		switch mode {
+		case outOfZoneError:
+			if len(outside) > 0 {
+				diags.Append(zoneErrorDiagnostics("Out-of-zone record", errors.Join(outside...), zoneSourceAttribute(cfg.Path))...)
+				return nil, "", diags
+			}
+		case outOfZoneDrop:
+			rrs = inZone
+		default:
+			diags.Append(zoneWarningDiagnostics("Out-of-zone record", outside, zoneSourceAttribute(cfg.Path))...)
+		}
+	}
+
+	if mode := cfg.TrailingDots.ValueString(); mode != trailingDotsIgnore {
+		suspects := checkTrailingDots(rrs)
+		if len(suspects) > 0 && mode == trailingDotsError {
+			diags.Append(zoneErrorDiagnostics("Possible missing trailing dot", errors.Join(suspects...), zoneSourceAttribute(cfg.Path))...)
+			return nil, "", diags
+		}
+		diags.Append(zoneWarningDiagnostics("Possible missing trailing dot", suspects, zoneSourceAttribute(cfg.Path))...)
+	}
+
+	return rrs, origin, diags
+}
+
+// zoneSourceAttribute returns the path of the attribute that holds the zone
+// file for a data source, for errors in the file to point at.
+func zoneSourceAttribute(zonePath types.String) path.Path {
+	if zonePath.IsNull() {
+		return path.Root("content")
+	}
+	return path.Root("path")
+}
+
+// zoneErrorDiagnostics returns an error diagnostic for each of the errors that
+// readZone or groupRRs joins into err, attached to the attribute that holds
+// the zone file. If readZone stopped at its error limit, that's a warning.
+func zoneErrorDiagnostics(summary string, err error, attr path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, err := range zoneErrors(err) {
+		if errors.As(err, new(stoppedError)) {
+			diags.AddAttributeWarning(attr, "Too many errors in zone file", err.Error()+"; raise max_errors to report more")
+			continue
+		}
+		diags.AddAttributeError(attr, summary, errorDetail(err))
+	}
+	return diags
+}
+
+// zoneWarningDiagnostics returns a warning diagnostic for each of errs, which
+// describe problems in a zone file that the provider has worked around.
+func zoneWarningDiagnostics(summary string, errs []error, attr path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		diags.AddAttributeWarning(attr, summary, errorDetail(err))
+	}
+	return diags
+}
+
+// validateOneOf ensures that a string attribute, if known and not null, has
+// one of the given values.
+func validateOneOf(ctx context.Context, config tfsdk.Config, resp *datasource.ValidateConfigResponse, name string, values ...string) {
+	var value types.String
+	resp.Diagnostics.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if value.IsNull() || value.IsUnknown() || lo.Contains(values, value.ValueString()) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root(name),
+		"Invalid "+name,
+		fmt.Sprintf("%s must be one of %s, not %q.", name, quotedList(values), value.ValueString()))
+}
+
+// quotedList formats a list of values like `"a", "b", or "c"`.
+func quotedList(values []string) string {
+	quoted := lo.Map(values, func(v string, _ int) string { return strconv.Quote(v) })
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	if len(quoted) == 2 {
+		return quoted[0] + " or " + quoted[1]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+}
+
+// maxErrorsValue returns the error limit for readZone from the max_errors
+// attribute of a data source.
+func maxErrorsValue(maxErrors types.Int64) int {
+	if maxErrors.IsNull() {
+		return defaultMaxErrors
+	}
+	return int(maxErrors.ValueInt64())
+}
@@ -1,27 +1,137 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/miekg/dns"
+	"github.com/samber/lo"
 )
 
-func readZone(origin, content string) ([]dns.RR, error) {
-	var rrs []dns.RR
-	parser := dns.NewZoneParser(strings.NewReader(content), origin, "")
-	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
-		rrs = append(rrs, rr)
+// zoneSource represents a zone file to parse.
+type zoneSource struct {
+	Origin  string
+	Content string
+	// File is the name of the zone file in error messages, and the base for
+	// relative $INCLUDE paths.
+	File string
+	// Includes is the file system that $INCLUDE directives read from. If nil,
+	// the zone file may not use $INCLUDE.
+	Includes fs.FS
+	// MaxErrors is the number of errors to report before giving up on the zone
+	// file. Values less than 1 report only the first error.
+	MaxErrors int
+}
+
+// defaultMaxErrors is the default for the max_errors attribute of the data
+// sources, and the error limit for functions.
+const defaultMaxErrors = 10
+
+// zoneRR is an RR along with its location in a zone file.
+type zoneRR struct {
+	RR   dns.RR
+	File *zoneFile
+	// Line is the line where the RR's entry starts, or the line of the
+	// $GENERATE directive that produced it.
+	Line int
+	// Generated is true if a $GENERATE directive produced the RR.
+	Generated bool
+	// Origin is the origin in effect for the RR's entry, from the initial
+	// origin or the last $ORIGIN directive before it, or empty if there is none.
+	Origin string
+	// Comment is the text of any comments in the RR's entry, including the
+	// leading semicolons, as given by [dns.ZoneParser.Comment].
+	Comment string
+	// Metadata holds the annotations in Comment, per parseAnnotations.
+	Metadata map[string]string
+}
+
+// position describes the location of the RR in error messages, naming its
+// file only if it differs from the file of other.
+func (rr zoneRR) position(other zoneRR) string {
+	if rr.File != nil && rr.File != other.File && rr.File.Name != "" {
+		return fmt.Sprintf("line %d of %s", rr.Line, rr.File.Name)
+	}
+	return fmt.Sprintf("line %d", rr.Line)
+}
+
+// zone is the result of parsing a zone file.
+type zone struct {
+	RRs []zoneRR
+	// DirectiveOrigin is the origin set by the first $ORIGIN directive in the
+	// zone file (outside of any included files), or empty if there is none.
+	DirectiveOrigin string
+	// Warnings are problems with the zone file that don't affect its RRs, like
+	// malformed annotations, each as a *zoneError.
+	Warnings []error
+}
+
+// soaOrigin returns the owner name of the first SOA record in the zone, or
+// an empty string if there is none.
+func (z zone) soaOrigin() string {
+	soa, ok := lo.Find(z.RRs, func(rr zoneRR) bool { return rr.RR.Header().Rrtype == dns.TypeSOA })
+	if !ok {
+		return ""
 	}
-	return rrs, parser.Err()
+	return soa.RR.Header().Name
+}
+
+// readZone parses the RRs in a zone file. If the file has errors, readZone
+// returns all of them (up to src.MaxErrors) joined with [errors.Join], each
+// as a *zoneError where possible.
+func readZone(src zoneSource) (zone, error) {
+	file := &zoneFile{Name: src.File, Content: src.Content}
+	origin := src.Origin
+	if origin != "" {
+		origin = dns.Fqdn(origin)
+		if _, ok := dns.IsDomainName(origin); !ok {
+			return zone{}, &zoneError{File: file, Err: "bad initial origin name", Token: src.Origin}
+		}
+	}
+
+	scanner := zoneScanner{
+		includes:  src.Includes,
+		maxErrors: max(src.MaxErrors, 1),
+	}
+	scanner.scan(zoneState{file: file, origin: origin})
+	if len(scanner.errs) > 0 {
+		return zone{}, errors.Join(scanner.errs...)
+	}
+	return zone{RRs: scanner.rrs, DirectiveOrigin: scanner.directiveOrigin, Warnings: scanner.warnings}, nil
 }
 
 type rrSet struct {
 	Hdr dns.RR_Header
-	RRs []dns.RR
+	RRs []zoneRR
+	// Origin is the origin in effect for the first RR of the set in the zone
+	// file, which may not be RRs[0] once the set is sorted.
+	Origin string
+	// Metadata is the union of the metadata of every RR in the set.
+	Metadata map[string]string
 }
 
-func groupRRs(rrs []dns.RR) ([]rrSet, error) {
+// Ways to resolve inconsistent TTLs in an RRSet, as values of the ttl_conflict
+// attribute.
+const (
+	ttlConflictError = "error"
+	ttlConflictMin   = "min"
+	ttlConflictMax   = "max"
+	ttlConflictFirst = "first"
+)
+
+// groupRRs groups RRs into RRSets. Since DNS names are case-insensitive,
+// RRSets group RRs by the canonical form of their owner names, and take their
+// headers from the first RR in each set.
+//
+// If any RRSets have RRs with inconsistent metadata, or TTLs that ttlConflict
+// doesn't resolve, groupRRs returns an error for each of them joined with
+// [errors.Join]. Otherwise, it returns a warning for each RRSet whose TTL it
+// had to resolve.
+func groupRRs(rrs []zoneRR, ttlConflict string) ([]rrSet, []error, error) {
 	type key struct {
 		Name   string
 		Class  uint16
@@ -30,40 +140,300 @@ func groupRRs(rrs []dns.RR) ([]rrSet, error) {
 
 	// Care is taken to order RRSets based on the ordering of the original RRs.
 	// This is friendly to the unit tests, and to users of count in Terraform
-	// (though I'd recommend using for_each and inventing keys with
-	// md5(jsonencode(rrset)) or similar).
+	// (though I'd recommend using for_each with rrsets_by_key, whose keys come
+	// from rrSetKey).
 	var rrSets []rrSet
 	indices := make(map[key]int)
 	for _, rr := range rrs {
-		hdr := *rr.Header()
-		k := key{hdr.Name, hdr.Class, hdr.Rrtype}
+		hdr := *rr.RR.Header()
+		k := key{dns.CanonicalName(hdr.Name), hdr.Class, hdr.Rrtype}
 		if i, ok := indices[k]; ok {
 			rrSets[i].RRs = append(rrSets[i].RRs, rr)
 		} else {
 			newRRSet := rrSet{
 				Hdr: hdr, // hdr.Rdlength may be inconsistent, but we don't care about it.
-				RRs: []dns.RR{rr},
+				RRs: []zoneRR{rr},
+
+				Origin: rr.Origin,
 			}
 			rrSets = append(rrSets, newRRSet)
 			indices[k] = len(rrSets) - 1
 		}
 	}
 
-	for _, set := range rrSets {
-		ttl := set.Hdr.Ttl
-		for _, rr := range set.RRs {
-			hdr := rr.Header()
-			if hdr.Ttl != ttl {
-				return nil, fmt.Errorf(
-					"inconsistent TTLs between %s %s %s records (%d vs. %d); see RFC 2181 section 5.2",
-					dns.ClassToString[hdr.Class],
-					dns.TypeToString[hdr.Rrtype],
-					hdr.Name,
-					ttl, hdr.Ttl,
-				)
+	var errs, warnings []error
+	for i, set := range rrSets {
+		first := set.RRs[0]
+		rr, ok := lo.Find(set.RRs[1:], func(rr zoneRR) bool { return rr.RR.Header().Ttl != set.Hdr.Ttl })
+		if !ok {
+			continue
+		}
+
+		hdr := rr.RR.Header()
+		msg := fmt.Sprintf(
+			"inconsistent TTLs between %s %s %s records (%d at %s vs. %d at line %d)",
+			dns.ClassToString[hdr.Class],
+			dns.TypeToString[hdr.Rrtype],
+			hdr.Name,
+			set.Hdr.Ttl, first.position(rr),
+			hdr.Ttl, rr.Line,
+		)
+		ttls := lo.Map(set.RRs, func(rr zoneRR, _ int) uint32 { return rr.RR.Header().Ttl })
+		switch ttlConflict {
+		case ttlConflictMin:
+			rrSets[i].Hdr.Ttl = lo.Min(ttls)
+			msg += fmt.Sprintf("; using the lowest TTL (%d) per RFC 2181 section 5.2", rrSets[i].Hdr.Ttl)
+		case ttlConflictMax:
+			rrSets[i].Hdr.Ttl = lo.Max(ttls)
+			msg += fmt.Sprintf("; using the highest TTL (%d)", rrSets[i].Hdr.Ttl)
+		case ttlConflictFirst:
+			msg += fmt.Sprintf("; using the first TTL (%d)", rrSets[i].Hdr.Ttl)
+		default:
+			errs = append(errs, &zoneError{File: rr.File, Line: rr.Line, Err: msg + "; see RFC 2181 section 5.2"})
+			continue
+		}
+		warnings = append(warnings, &zoneError{File: rr.File, Line: rr.Line, Err: msg})
+	}
+
+	for i, set := range rrSets {
+		var err error
+		rrSets[i].Metadata, err = mergeMetadata(set.RRs)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return rrSets, warnings, nil
+}
+
+// mergeMetadata combines the metadata of the RRs in an RRSet, which may set
+// different keys but must not give different values to the same key.
+func mergeMetadata(rrs []zoneRR) (map[string]string, error) {
+	metadata := make(map[string]string)
+	from := make(map[string]zoneRR)
+	for _, rr := range rrs {
+		keys := lo.Keys(rr.Metadata)
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := rr.Metadata[k]
+			prev, ok := from[k]
+			if !ok {
+				metadata[k], from[k] = v, rr
+				continue
+			}
+			if metadata[k] != v {
+				hdr := rr.RR.Header()
+				return nil, &zoneError{
+					File: rr.File,
+					Line: rr.Line,
+					Err: fmt.Sprintf(
+						"inconsistent %q metadata between %s %s %s records (%q at %s vs. %q at line %d)",
+						k,
+						dns.ClassToString[hdr.Class],
+						dns.TypeToString[hdr.Rrtype],
+						hdr.Name,
+						metadata[k], prev.position(rr),
+						v, rr.Line,
+					),
+				}
+			}
+		}
+	}
+	return metadata, nil
+}
+
+// dedupeRRs removes RRs that duplicate an earlier RR in the same RRSet, which
+// RFC 2181 section 5 says are the same RR. It returns the remaining RRs, along
+// with an error for each duplicate that names the line of the original.
+func dedupeRRs(rrs []zoneRR) ([]zoneRR, []error) {
+	type key struct {
+		Name   string
+		Class  uint16
+		Rrtype uint16
+	}
+
+	var (
+		unique []zoneRR
+		errs   []error
+	)
+	seen := make(map[key][]zoneRR)
+	for _, rr := range rrs {
+		hdr := rr.RR.Header()
+		k := key{dns.CanonicalName(hdr.Name), hdr.Class, hdr.Rrtype}
+		prev, dup := lo.Find(seen[k], func(prev zoneRR) bool { return dns.IsDuplicate(prev.RR, rr.RR) })
+		if !dup {
+			seen[k] = append(seen[k], rr)
+			unique = append(unique, rr)
+			continue
+		}
+		errs = append(errs, &zoneError{
+			File: rr.File,
+			Line: rr.Line,
+			Err: fmt.Sprintf(
+				"duplicate %s %s %s record (first at %s); see RFC 2181 section 5",
+				dns.ClassToString[hdr.Class],
+				dns.TypeToString[hdr.Rrtype],
+				hdr.Name,
+				prev.position(rr),
+			),
+		})
+	}
+	return unique, errs
+}
+
+// splitOutOfZone returns the RRs whose owner names are at or below origin,
+// along with an error for each RR outside of that zone.
+func splitOutOfZone(rrs []zoneRR, origin string) ([]zoneRR, []error) {
+	var (
+		inZone []zoneRR
+		errs   []error
+	)
+	for _, rr := range rrs {
+		hdr := rr.RR.Header()
+		if dns.IsSubDomain(origin, hdr.Name) {
+			inZone = append(inZone, rr)
+			continue
+		}
+		errs = append(errs, &zoneError{
+			File: rr.File,
+			Line: rr.Line,
+			Err: fmt.Sprintf(
+				"%s %s %s record is outside of the zone %s",
+				dns.ClassToString[hdr.Class],
+				dns.TypeToString[hdr.Rrtype],
+				hdr.Name,
+				origin,
+			),
+		})
+	}
+	return inZone, errs
+}
+
+// hostManagedType returns dns.TypeSOA or dns.TypeNS if an RR header is for
+// an SOA record or an NS record at the zone apex, which DNS hosts usually
+// manage themselves, or dns.TypeNone otherwise.
+func hostManagedType(hdr *dns.RR_Header, apex string) uint16 {
+	switch {
+	case hdr.Rrtype == dns.TypeSOA:
+		return dns.TypeSOA
+	case hdr.Rrtype == dns.TypeNS && apex != "" && dns.CanonicalName(hdr.Name) == dns.CanonicalName(apex):
+		return dns.TypeNS
+	}
+	return dns.TypeNone
+}
+
+// normalizeNames replaces the owner name of each RR, and the origin in effect
+// for it, with its canonical form per RFC 4034 section 6.2, which is lowercase.
+func normalizeNames(rrs []zoneRR) {
+	for i := range rrs {
+		hdr := rrs[i].RR.Header()
+		hdr.Name = dns.CanonicalName(hdr.Name)
+		if rrs[i].Origin != "" {
+			rrs[i].Origin = dns.CanonicalName(rrs[i].Origin)
+		}
+	}
+}
+
+// readRR parses a single RR in presentation format. Like a zone file, the RR
+// may use names relative to origin, and may omit its TTL in favor of defaultTTL
+// if non-nil.
+func readRR(content, origin string, defaultTTL *uint32) (dns.RR, error) {
+	parser := dns.NewZoneParser(strings.NewReader(content), origin, "")
+	if defaultTTL != nil {
+		parser.SetDefaultTTL(*defaultTTL)
+	}
+	rr, ok := parser.Next()
+	if err := parser.Err(); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("no resource record found")
+	}
+	if _, ok := parser.Next(); ok {
+		return nil, errors.New("found more than one resource record")
+	}
+	if err := parser.Err(); err != nil {
+		return nil, err
+	}
+
+	// The parser only requires a TTL for records without a class, and gives
+	// records like "www IN A 10.0.0.1" a TTL of 0. To tell whether a TTL of 0
+	// came from the record itself, parse it again with a different default.
+	if defaultTTL == nil && rr.Header().Ttl == 0 {
+		if other, err := readRR(content, origin, lo.ToPtr(uint32(1))); err == nil && other.Header().Ttl != 0 {
+			return nil, errors.New("missing TTL with no default TTL")
+		}
+	}
+	return rr, nil
+}
+
+// formatZone renders RRs as a zone file, using names relative to origin where
+// possible and omitting TTLs equal to the default given by ttl. If ttl is nil,
+// the file has no $TTL directive and every RR has an explicit TTL.
+func formatZone(rrs []dns.RR, origin string, ttl *uint32) string {
+	var b strings.Builder
+	if origin != "" {
+		fmt.Fprintf(&b, "$ORIGIN %s\n", dns.Fqdn(origin))
+	}
+	if ttl != nil {
+		fmt.Fprintf(&b, "$TTL %d\n", *ttl)
+	}
+	if len(rrs) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n")
+
+	rows := lo.Map(rrs, func(rr dns.RR, _ int) [5]string {
+		hdr := rr.Header()
+		return [5]string{
+			relativeName(hdr.Name, origin),
+			lo.Ternary(ttl != nil && hdr.Ttl == *ttl, "", strconv.FormatUint(uint64(hdr.Ttl), 10)),
+			dns.ClassToString[hdr.Class],
+			dns.TypeToString[hdr.Rrtype],
+			strings.TrimPrefix(rr.String(), hdr.String()),
+		}
+	})
+
+	var widths [4]int
+	for _, row := range rows {
+		for i := range widths {
+			widths[i] = max(widths[i], len(row[i]))
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for i, width := range widths {
+			if width > 0 {
+				fmt.Fprintf(&line, "%-*s ", width, row[i])
 			}
 		}
+		line.WriteString(row[4])
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteString("\n")
 	}
+	return b.String()
+}
 
-	return rrSets, nil
+// relativeName returns a name relative to origin for use in a zone file, or
+// the fully qualified name if it is not at or below origin.
+func relativeName(fqdn, origin string) string {
+	if origin == "" {
+		return fqdn
+	}
+	origin = dns.Fqdn(origin)
+	switch {
+	case strings.EqualFold(fqdn, origin):
+		return "@"
+	case origin == ".":
+		return fqdn
+	case dns.IsSubDomain(origin, fqdn):
+		return fqdn[:len(fqdn)-len(origin)-1]
+	default:
+		return fqdn
+	}
 }
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// zoneFile is the content of a zone file, or of a file that it includes.
+type zoneFile struct {
+	Name    string
+	Content string
+}
+
+// lines splits the file into lines, without line endings.
+func (f *zoneFile) lines() []string {
+	if f == nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(f.Content, "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	return lines
+}
+
+// zoneError is an error at a particular location in a zone file.
+type zoneError struct {
+	// File is the file containing the error, if known.
+	File *zoneFile
+	// Line and Column count from 1, and are 0 if unknown. Column is the start
+	// of Token within the line.
+	Line   int
+	Column int
+	Token  string
+	Err    string
+}
+
+func (e *zoneError) Error() string {
+	var b strings.Builder
+	if e.File != nil && e.File.Name != "" {
+		b.WriteString(e.File.Name + ": ")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, ", column %d", e.Column)
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Err)
+	if e.Token != "" {
+		b.WriteString(": " + strconv.QuoteToASCII(e.Token))
+	}
+	return b.String()
+}
+
+// stoppedError notes that readZone gave up on a zone file after reaching its
+// error limit. It follows the errors that it counts, but isn't an error in the
+// zone file itself, so data sources report it as a warning.
+type stoppedError struct {
+	Errors int
+}
+
+func (e stoppedError) Error() string {
+	if e.Errors == 1 {
+		return "stopped parsing after 1 error"
+	}
+	return fmt.Sprintf("stopped parsing after %d errors", e.Errors)
+}
+
+// snippetContext is the number of lines to show before and after the line
+// with an error.
+const snippetContext = 2
+
+// Detail describes the error along with the lines of the zone file around it,
+// with a caret under the offending token:
+//
+//	example.zone: line 3, column 8: bad A A: "not-an-ip"
+//
+//	  1 | $TTL 3600
+//	  2 | a IN A 10.100.0.10
+//	  3 | b IN A not-an-ip
+//	    |        ^^^^^^^^^
+//	  4 | c IN A 10.100.0.12
+//
+// The snippet is indented so that Terraform treats it as preformatted text,
+// and doesn't wrap it with the rest of the message.
+func (e *zoneError) Detail() string {
+	lines := e.File.lines()
+	if e.Line < 1 || e.Line > len(lines) {
+		return e.Error()
+	}
+
+	first, last := max(e.Line-snippetContext, 1), min(e.Line+snippetContext, len(lines))
+	width := len(strconv.Itoa(last))
+
+	var b strings.Builder
+	b.WriteString(e.Error() + "\n")
+	for n := first; n <= last; n++ {
+		text := lines[n-1]
+		b.WriteString("\n" + strings.TrimRight(fmt.Sprintf("  %*d | %s", width, n, text), " \t"))
+		if n == e.Line && e.Column > 0 {
+			fmt.Fprintf(&b, "\n  %*s | %s", width, "", caret(text, e.Column, len(e.Token)))
+		}
+	}
+	return b.String()
+}
+
+// caret returns a line that marks the given span of text when printed under
+// it, keeping any tabs so that the marker lines up.
+func caret(text string, column, length int) string {
+	start := min(column-1, len(text))
+	indent := []byte(text[:start])
+	for i, c := range indent {
+		if c != '\t' {
+			indent[i] = ' '
+		}
+	}
+	length = max(min(length, len(text)-start), 1)
+	return string(indent) + strings.Repeat("^", length)
+}
+
+// newZoneError relocates an error from parsing a single entry of a zone file
+// to its position in the whole file, given the line where the entry starts
+// and the length of any prefix added to the entry's first line.
+//
+// miekg/dns doesn't expose the details of its *dns.ParseError, so we recover
+// them from its message.
+func newZoneError(err error, file *zoneFile, line, prefix int) error {
+	msg := err.Error()
+	if file.Name != "" {
+		msg = strings.TrimPrefix(msg, file.Name+": ")
+	}
+	msg, ok := strings.CutPrefix(msg, "dns: ")
+	match := parseErrorPosition.FindStringSubmatchIndex(msg)
+	if !ok || match == nil {
+		return &zoneError{File: file, Line: line, Err: msg}
+	}
+
+	token, _ := strconv.Unquote(msg[match[2]:match[3]])
+	entryLine, _ := strconv.Atoi(msg[match[4]:match[5]])
+	column, _ := strconv.Atoi(msg[match[6]:match[7]])
+	if entryLine <= 1 {
+		column = max(column-prefix, 0)
+	}
+	e := &zoneError{
+		File:  file,
+		Line:  line + max(entryLine, 1) - 1,
+		Token: token,
+		Err:   msg[:match[0]],
+	}
+	e.Column = tokenColumn(file, e.Line, token, column)
+	return e
+}
+
+var parseErrorPosition = regexp.MustCompile(`: ("(?:[^"\\]|\\.)*") at line: (\d+):(\d+)$`)
+
+// tokenColumn finds where token starts on a line of a zone file, given the
+// column where miekg/dns stopped reading it. That's usually just past the end
+// of the token, but not always, so we look back for the token itself. If it
+// isn't there (for example, because it was unescaped) we give up and use the
+// column as is.
+func tokenColumn(file *zoneFile, line int, token string, column int) int {
+	lines := file.lines()
+	if line < 1 || line > len(lines) || token == "" || column < 1 {
+		return column
+	}
+	text := lines[line-1]
+	if i := strings.LastIndex(text[:min(column, len(text))], token); i >= 0 {
+		return i + 1
+	}
+	return column
+}
+
+// zoneErrors splits an error joined with [errors.Join], like those returned
+// by readZone, into its parts.
+func zoneErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// errorDetail describes an error from readZone or groupRRs for display to
+// users, with the surrounding source if available.
+func errorDetail(err error) string {
+	var zerr *zoneError
+	if errors.As(err, &zerr) {
+		return zerr.Detail()
+	}
+	return err.Error()
+}
+
+// errorsDetail describes every error that readZone or groupRRs joins into err,
+// for functions that can only return a single message.
+func errorsDetail(err error) string {
+	var details []string
+	for _, err := range zoneErrors(err) {
+		details = append(details, errorDetail(err))
+	}
+	return strings.Join(details, "\n\n")
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/miekg/dns"
+	"github.com/samber/lo"
+)
+
+// recordFilter selects RRs by the filtering attributes of a data source. Each
+// kind of filter that isn't set includes every RR.
+type recordFilter struct {
+	includeTypes   map[uint16]bool
+	excludeTypes   map[uint16]bool
+	includeClasses map[uint16]bool
+	includeNames   []namePattern
+	excludeNames   []namePattern
+}
+
+// namePattern matches the names of an RR, per the include_names and
+// exclude_names attributes.
+type namePattern func(names []string) bool
+
+// newRecordFilter parses the filtering attributes of a data source.
+func newRecordFilter(cfg zoneConfig) (recordFilter, diag.Diagnostics) {
+	var (
+		f     recordFilter
+		diags diag.Diagnostics
+	)
+	f.includeTypes = parseTypeFilter("include_types", cfg.IncludeTypes, &diags)
+	f.excludeTypes = parseTypeFilter("exclude_types", cfg.ExcludeTypes, &diags)
+	f.includeClasses = parseClassFilter("include_classes", cfg.IncludeClasses, &diags)
+	f.includeNames = parseNameFilter("include_names", cfg.IncludeNames, &diags)
+	f.excludeNames = parseNameFilter("exclude_names", cfg.ExcludeNames, &diags)
+	return f, diags
+}
+
+func parseTypeFilter(name string, values []string, diags *diag.Diagnostics) map[uint16]bool {
+	if values == nil {
+		return nil
+	}
+	types := make(map[uint16]bool)
+	for i, v := range values {
+		t, ok := dns.StringToType[strings.ToUpper(v)]
+		if !ok {
+			diags.AddAttributeError(tfpath.Root(name).AtListIndex(i),
+				"Invalid "+name,
+				fmt.Sprintf("%q is not a known record type.", v))
+			continue
+		}
+		types[t] = true
+	}
+	return types
+}
+
+func parseClassFilter(name string, values []string, diags *diag.Diagnostics) map[uint16]bool {
+	if values == nil {
+		return nil
+	}
+	classes := make(map[uint16]bool)
+	for i, v := range values {
+		c, ok := dns.StringToClass[strings.ToUpper(v)]
+		if !ok {
+			diags.AddAttributeError(tfpath.Root(name).AtListIndex(i),
+				"Invalid "+name,
+				fmt.Sprintf("%q is not a known record class.", v))
+			continue
+		}
+		classes[c] = true
+	}
+	return classes
+}
+
+// parseNameFilter parses name patterns, which are regular expressions if
+// enclosed in slashes (like "/^_.*/") and globs otherwise (like "*.dev").
+// Both kinds of pattern are case-insensitive.
+func parseNameFilter(name string, values []string, diags *diag.Diagnostics) []namePattern {
+	if values == nil {
+		return nil
+	}
+	patterns := make([]namePattern, 0, len(values))
+	for i, v := range values {
+		if len(v) >= 2 && strings.HasPrefix(v, "/") && strings.HasSuffix(v, "/") {
+			re, err := regexp.Compile(v[1 : len(v)-1])
+			if err == nil {
+				re, err = regexp.Compile("(?i)" + re.String())
+			}
+			if err != nil {
+				diags.AddAttributeError(tfpath.Root(name).AtListIndex(i),
+					"Invalid "+name,
+					fmt.Sprintf("%q is not a valid regular expression: %v.", v, err))
+				continue
+			}
+			patterns = append(patterns, func(names []string) bool {
+				return lo.SomeBy(names, re.MatchString)
+			})
+			continue
+		}
+
+		glob := strings.ToLower(v)
+		if _, err := path.Match(glob, ""); err != nil {
+			diags.AddAttributeError(tfpath.Root(name).AtListIndex(i),
+				"Invalid "+name,
+				fmt.Sprintf("%q is not a valid glob pattern.", v))
+			continue
+		}
+		patterns = append(patterns, func(names []string) bool {
+			return lo.SomeBy(names, func(n string) bool {
+				ok, _ := path.Match(glob, strings.ToLower(n))
+				return ok
+			})
+		})
+	}
+	return patterns
+}
+
+// apply returns the RRs that the filter includes. Name patterns match the
+// name of an RR relative to origin ("@" for the apex), and its FQDN with or
+// without the trailing dot.
+func (f recordFilter) apply(rrs []zoneRR, origin string) []zoneRR {
+	return lo.Filter(rrs, func(rr zoneRR, _ int) bool {
+		hdr := rr.RR.Header()
+		if f.includeTypes != nil && !f.includeTypes[hdr.Rrtype] {
+			return false
+		}
+		if f.excludeTypes[hdr.Rrtype] {
+			return false
+		}
+		if f.includeClasses != nil && !f.includeClasses[hdr.Class] {
+			return false
+		}
+		if f.includeNames == nil && f.excludeNames == nil {
+			return true
+		}
+
+		names := []string{hdr.Name, strings.TrimSuffix(hdr.Name, ".")}
+		if origin != "" {
+			name := nameModelValue(hdr.Name, origin)
+			names = append(names, lo.Ternary(name.IsNull(), "@", name.ValueString()))
+		}
+		matches := func(p namePattern) bool { return p(names) }
+		if f.includeNames != nil && !lo.SomeBy(f.includeNames, matches) {
+			return false
+		}
+		return !lo.SomeBy(f.excludeNames, matches)
+	})
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &FormatFunction{}
+
+type FormatFunction struct{}
+
+func NewFormatFunction() function.Function {
+	return &FormatFunction{}
+}
+
+func (f *FormatFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format"
+}
+
+func (f *FormatFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render a DNS zone file from a list of resource records.",
+		Description: ("Render a DNS zone file from a list of resource records, " +
+			"with the same behavior as the zonefile_content data source. " +
+			"The output of the records function is suitable as-is. " +
+			"The most common TTL among the records becomes the default TTL for the file, " +
+			"and an empty list of records renders a file with no $TTL directive."),
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "records",
+				ElementType: attributeObjectContentRecordModel.Type(),
+				Description: ("The resource records to write to the zone file, in order. " +
+					"Each record must include name, fqdn, class, type, ttl, and data attributes, " +
+					"though any of these except type and data may be null."),
+			},
+			function.StringParameter{
+				Name:           "origin",
+				AllowNullValue: true,
+				Description: ("The origin to declare with an $ORIGIN directive at the top of the file. " +
+					"If set, the provider will write record names relative to the origin where possible."),
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FormatFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var records []ContentRecordModel
+	var origin types.String
+	resp.Error = req.Arguments.Get(ctx, &records, &origin)
+	if resp.Error != nil {
+		return
+	}
+
+	content, err := formatContent(records, origin.ValueString(), types.Int64Null())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Can't render zone file: "+err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, content)
+}
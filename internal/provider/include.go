@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// newZoneSource prepares to parse a zone file given either its content, or
+// the path to a file on disk. When reading from content, $INCLUDE directives
+// may read from the includes map if non-nil. When reading from a file, they
+// may read from disk if includeRoot is set and contains the file.
+func newZoneSource(origin, content, file string, includes map[string]string, includeRoot string) (zoneSource, error) {
+	if file == "" {
+		return zoneSource{Origin: origin, Content: content, Includes: newIncludeFS(includes)}, nil
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return zoneSource{}, err
+	}
+	src := zoneSource{Origin: origin, Content: string(b), File: file}
+	if includeRoot == "" {
+		return src, nil
+	}
+
+	root, err := resolvePath(includeRoot)
+	if err != nil {
+		return zoneSource{}, err
+	}
+	resolved, err := resolvePath(file)
+	if err != nil {
+		return zoneSource{}, err
+	}
+	if rel, err := filepath.Rel(root, resolved); err == nil && filepath.IsLocal(rel) {
+		src.File = filepath.ToSlash(rel)
+		src.Includes = rootFS(root)
+	}
+	return src, nil
+}
+
+func resolvePath(name string) (string, error) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// rootFS is an [fs.FS] of files on disk that $INCLUDE directives can read.
+// Unlike [os.DirFS], it refuses to follow symbolic links outside of its root.
+type rootFS string
+
+func (root rootFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(string(root), filepath.FromSlash(name)))
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		}
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if rel, err := filepath.Rel(string(root), resolved); err != nil || !filepath.IsLocal(rel) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return os.Open(resolved)
+}
+
+// includeFS is an in-memory [fs.FS] of files that $INCLUDE directives can
+// read, keyed by slash-separated paths relative to the including file.
+type includeFS map[string]string
+
+// newIncludeFS returns an [fs.FS] serving the provided files, or nil if files
+// is nil.
+func newIncludeFS(files map[string]string) fs.FS {
+	if files == nil {
+		return nil
+	}
+	fsys := make(includeFS, len(files))
+	for name, content := range files {
+		fsys[cleanIncludePath(name)] = content
+	}
+	return fsys
+}
+
+func cleanIncludePath(name string) string {
+	return strings.TrimLeft(path.Clean(name), "/")
+}
+
+func (fsys includeFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	content, ok := fsys[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &includeFile{Reader: strings.NewReader(content), name: name}, nil
+}
+
+var _ fs.File = &includeFile{}
+var _ fs.FileInfo = &includeFile{}
+
+type includeFile struct {
+	*strings.Reader
+	name string
+}
+
+func (f *includeFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *includeFile) Close() error               { return nil }
+
+func (f *includeFile) Name() string       { return path.Base(f.name) }
+func (f *includeFile) Size() int64        { return f.Reader.Size() }
+func (f *includeFile) Mode() fs.FileMode  { return 0o444 }
+func (f *includeFile) ModTime() time.Time { return time.Time{} }
+func (f *includeFile) IsDir() bool        { return false }
+func (f *includeFile) Sys() any           { return nil }
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// rrSetKey identifies an RRSet by its canonical name relative to origin, type,
+// and class, like "www/A/IN". Unlike an index into a list of RRSets, the key stays
+// the same as other RRSets come and go, and unlike a hash of the entire RRSet
+// it stays the same as the RRSet's data changes. That makes it a good key for
+// for_each in Terraform.
+func rrSetKey(hdr *dns.RR_Header, origin string) string {
+	return fmt.Sprintf("%s/%s/%s",
+		relativeName(dns.CanonicalName(hdr.Name), strings.ToLower(origin)),
+		dns.TypeToString[hdr.Rrtype],
+		dns.ClassToString[hdr.Class])
+}
+
+// recordKeys returns a key for each RR, formed from the key of its RRSet and
+// a short hash of its data, like "www/A/IN/5d3c0f2a". If RRs are exactly
+// duplicated, the second and later copies get a numeric suffix ("-2", "-3",
+// and so on) in order to keep the keys unique.
+func recordKeys(rrs []zoneRR, origin string) []string {
+	keys := make([]string, len(rrs))
+	seen := make(map[string]int)
+	for i, rr := range rrs {
+		sum := sha256.Sum256([]byte(rdataModelValue(rr.RR).ValueString()))
+		key := rrSetKey(rr.RR.Header(), origin) + "/" + hex.EncodeToString(sum[:4])
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s-%d", key, n)
+		}
+		keys[i] = key
+	}
+	return keys
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miekg/dns"
 	"github.com/samber/lo"
@@ -11,98 +12,462 @@ import (
 
 // RecordsModel represents the entire "zonefile_records" data source.
 type RecordsModel struct {
-	Content types.String `tfsdk:"content"`
-	Origin  types.String `tfsdk:"origin"`
+	Content        types.String      `tfsdk:"content"`
+	Path           types.String      `tfsdk:"path"`
+	Origin         types.String      `tfsdk:"origin"`
+	Includes       map[string]string `tfsdk:"includes"`
+	MaxErrors      types.Int64       `tfsdk:"max_errors"`
+	OriginMode     types.String      `tfsdk:"origin_mode"`
+	NormalizeNames types.Bool        `tfsdk:"normalize_names"`
+	Duplicates     types.String      `tfsdk:"duplicates"`
+	OutOfZone      types.String      `tfsdk:"out_of_zone"`
+	TrailingDots   types.String      `tfsdk:"missing_trailing_dots"`
+	Order          types.String      `tfsdk:"order"`
+	IncludeTypes   []string          `tfsdk:"include_types"`
+	ExcludeTypes   []string          `tfsdk:"exclude_types"`
+	IncludeNames   []string          `tfsdk:"include_names"`
+	ExcludeNames   []string          `tfsdk:"exclude_names"`
+	IncludeClasses []string          `tfsdk:"include_classes"`
+	ManagedByHost  types.Bool        `tfsdk:"managed_by_host"`
+
+	EffectiveOrigin types.String       `tfsdk:"effective_origin"`
+	SOA             *RecordsItemModel  `tfsdk:"soa"`
+	ApexNS          []RecordsItemModel `tfsdk:"apex_ns"`
 
-	Records []RecordsItemModel `tfsdk:"records"`
+	Records      []RecordsItemModel          `tfsdk:"records"`
+	RecordsByKey map[string]RecordsItemModel `tfsdk:"records_by_key"`
 }
 
 // RecordSetsModel represents the entire "zonefile_record_sets" data source.
 type RecordSetsModel struct {
-	Content types.String `tfsdk:"content"`
-	Origin  types.String `tfsdk:"origin"`
+	Content        types.String      `tfsdk:"content"`
+	Path           types.String      `tfsdk:"path"`
+	Origin         types.String      `tfsdk:"origin"`
+	Includes       map[string]string `tfsdk:"includes"`
+	MaxErrors      types.Int64       `tfsdk:"max_errors"`
+	OriginMode     types.String      `tfsdk:"origin_mode"`
+	NormalizeNames types.Bool        `tfsdk:"normalize_names"`
+	Duplicates     types.String      `tfsdk:"duplicates"`
+	OutOfZone      types.String      `tfsdk:"out_of_zone"`
+	TrailingDots   types.String      `tfsdk:"missing_trailing_dots"`
+	Order          types.String      `tfsdk:"order"`
+	IncludeTypes   []string          `tfsdk:"include_types"`
+	ExcludeTypes   []string          `tfsdk:"exclude_types"`
+	IncludeNames   []string          `tfsdk:"include_names"`
+	ExcludeNames   []string          `tfsdk:"exclude_names"`
+	IncludeClasses []string          `tfsdk:"include_classes"`
+	ManagedByHost  types.Bool        `tfsdk:"managed_by_host"`
+	TTLConflict    types.String      `tfsdk:"ttl_conflict"`
+
+	EffectiveOrigin types.String         `tfsdk:"effective_origin"`
+	SOA             *RecordSetsItemModel `tfsdk:"soa"`
+	ApexNS          *RecordSetsItemModel `tfsdk:"apex_ns"`
+
+	RRSets      []RecordSetsItemModel          `tfsdk:"rrsets"`
+	RRSetsByKey map[string]RecordSetsItemModel `tfsdk:"rrsets_by_key"`
+}
+
+// ContentModel represents the entire "zonefile_content" data source.
+type ContentModel struct {
+	Records []ContentRecordModel `tfsdk:"records"`
+	Origin  types.String         `tfsdk:"origin"`
+	TTL     types.Int64          `tfsdk:"ttl"`
 
-	RRSets []RecordSetsItemModel `tfsdk:"rrsets"`
+	Content types.String `tfsdk:"content"`
 }
 
 var schemaModelHead = map[string]schema.Attribute{
 	"content": schema.StringAttribute{
-		Required: true,
+		Optional: true,
 		Description: ("The entire zone file as a string. " +
-			"You can read this from disk with the file(…) function or local_file data source."),
+			"You can read this from disk with the file(…) function or local_file data source. " +
+			"Exactly one of \"content\" or \"path\" must be set."),
+	},
+	"path": schema.StringAttribute{
+		Optional: true,
+		Description: ("The path to a zone file on disk, relative to the working directory like the file(…) function. " +
+			"Unlike \"content\", error messages will name the file, and $INCLUDE directives can read other files " +
+			"within the include_root directory set in the provider configuration. " +
+			"Exactly one of \"content\" or \"path\" must be set."),
 	},
 	"origin": schema.StringAttribute{
 		Optional: true,
 		Description: ("The origin for relative record names in the file, " +
 			"equivalent to an $ORIGIN directive at the top of the file. " +
 			"If set, the provider will populate the \"name\" field of records. " +
-			"Otherwise, only \"fqdn\" will be available even if the file includes an $ORIGIN directive."),
+			"Otherwise, only \"fqdn\" will be available even if the file includes an $ORIGIN directive, " +
+			"unless \"origin_mode\" says to take the origin from the file."),
+	},
+	"origin_mode": schema.StringAttribute{
+		Optional: true,
+		Description: ("Where to find the origin that the \"name\" field of records is relative to. " +
+			"\"explicit\" uses the \"origin\" attribute. " +
+			"\"from_directive\" uses the first $ORIGIN directive in the zone file, " +
+			"and \"from_soa\" uses the owner name of the first SOA record; " +
+			"if the zone file has no such directive or record, these fall back to the \"origin\" attribute, " +
+			"and fail with an error if that isn't set either. " +
+			"In any case, \"origin\" remains the initial origin for parsing the zone file. Defaults to \"explicit\"."),
+	},
+	"effective_origin": schema.StringAttribute{
+		Computed: true,
+		Description: ("The origin that the \"name\" field of records is relative to, as chosen by \"origin_mode\", " +
+			"or null if there is none."),
+	},
+	"includes": schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: ("The contents of files that $INCLUDE directives in the zone file can read, " +
+			"keyed by path. A relative path in an included file resolves against the directory of that file, " +
+			"so \"$INCLUDE backup.zone\" in \"sub/main.zone\" reads the key \"sub/backup.zone\", " +
+			"while paths in the zone file itself are used as written. " +
+			"Paths and keys are cleaned, so \"./a.zone\" and \"a.zone\" are the same file. " +
+			"If not set, the provider will fail with an error if the zone file includes an $INCLUDE directive. " +
+			"The provider never reads $INCLUDE targets from disk when using this attribute, " +
+			"which conflicts with \"path\"."),
+	},
+	"max_errors": schema.Int64Attribute{
+		Optional: true,
+		Description: ("The maximum number of errors to report if the zone file fails to parse. " +
+			"After each error, the provider skips to the next line (or the end of a parenthesized entry) " +
+			"and continues to look for more errors, so that a single run can report all of them. " +
+			"Defaults to 10. Set to 1 to stop at the first error."),
+	},
+	"normalize_names": schema.BoolAttribute{
+		Optional: true,
+		Description: ("Whether to convert record names to their canonical lowercase form (per RFC 4034 section 6.2) " +
+			"in the \"fqdn\" and \"name\" attributes, along with origins in \"effective_origin\" and each \"origin\" attribute, " +
+			"rather than spelling them as written in the zone file. " +
+			"Since DNS names are case-insensitive, the provider groups RRSets and forms keys " +
+			"from canonical names either way. Defaults to false."),
+	},
+	"duplicates": schema.StringAttribute{
+		Optional: true,
+		Description: ("What to do with duplicate records, which have the same name, class, type, and data " +
+			"as an earlier record (regardless of TTL). Per RFC 2181 section 5, these are the same record. " +
+			"\"error\" fails with an error naming the lines of both records, " +
+			"\"warn\" removes the duplicates with a warning, " +
+			"and \"keep\" keeps every record exactly as written in the zone file. Defaults to \"keep\"."),
+	},
+	"out_of_zone": schema.StringAttribute{
+		Optional: true,
+		Description: ("What to do with records whose names aren't at or below the effective origin, " +
+			"which usually means a mistake like an absolute name in the wrong zone. " +
+			"\"error\" fails with an error naming each such record, " +
+			"\"warn\" keeps them with a warning, \"drop\" removes them, " +
+			"and \"allow\" keeps them as is. This has no effect if there is no origin. Defaults to \"warn\"."),
+	},
+	"missing_trailing_dots": schema.StringAttribute{
+		Optional: true,
+		Description: ("What to do with target names in the data of records like CNAME, MX, NS, and SRV " +
+			"that look like fully qualified names written without a trailing dot, " +
+			"so that the origin was appended to them (like \"web.example.com.example.com.\"). " +
+			"This flags targets in which the origin appears twice, or in which the part before the origin " +
+			"ends with the same top-level label as the origin (or in reverse zones, with any top-level domain like \"com\"). " +
+			"\"warn\" adds a warning for each such target, \"error\" fails with an error, " +
+			"and \"ignore\" skips the check. Defaults to \"warn\"."),
+	},
+	"order": schema.StringAttribute{
+		Optional: true,
+		Description: ("The order of records and RRSets. " +
+			"\"file\" keeps the order of the zone file, with each RRSet where its first record appears. " +
+			"\"canonical\" sorts by name in the canonical order of RFC 4034 section 6.1 " +
+			"(which puts names before the names below them), then by class and type number, " +
+			"then by the data of each record in canonical wire format (with lowercase names) as in RFC 4034 section 6.3. " +
+			"\"name_type\" sorts lexicographically by lowercase FQDN, then by type and class, " +
+			"keeping the order of the zone file within each RRSet. " +
+			"Sorting keeps indexes stable as lines move around in the zone file. Defaults to \"file\"."),
+	},
+	"include_types": schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: ("If set, only records of these types (like \"A\" or \"MX\") are included. " +
+			"Like the other filtering attributes, this applies before records are grouped into RRSets " +
+			"or checked for duplicates."),
+	},
+	"exclude_types": schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Records of these types are excluded.",
+	},
+	"include_names": schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: ("If set, only records with names that match one of these patterns are included. " +
+			"A pattern enclosed in slashes (like \"/^_acme-challenge/\") is a regular expression " +
+			"that may match any part of a name. Any other pattern is a glob, " +
+			"in which \"*\" matches any sequence of characters (including dots) and \"?\" matches any one character. " +
+			"Patterns are case-insensitive, and match the record's \"name\" (\"@\" for the zone apex) " +
+			"and its \"fqdn\" with or without the trailing dot."),
+	},
+	"exclude_names": schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: ("Records with names that match any of these patterns are excluded, " +
+			"even if \"include_names\" includes them. Patterns work as in \"include_names\"."),
+	},
+	"include_classes": schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "If set, only records of these classes (like \"IN\" or \"CH\") are included.",
+	},
+	"managed_by_host": schema.BoolAttribute{
+		Optional: true,
+		Description: ("Whether the DNS host manages the zone's SOA record and the NS records at its apex, " +
+			"as most hosts that you can manage with Terraform do. " +
+			"If true, these records are left out of the other attributes, " +
+			"but remain available in \"soa\" and \"apex_ns\" to compare with what the host reports. " +
+			"The apex is the effective origin, or else the owner name of the SOA record. Defaults to false."),
+	},
+}
+
+var functionParamsHead = []function.Parameter{
+	function.StringParameter{
+		Name:        "content",
+		Description: "The entire zone file as a string.",
+	},
+	function.StringParameter{
+		Name:           "origin",
+		AllowNullValue: true,
+		Description: ("The origin for relative record names in the file, " +
+			"equivalent to an $ORIGIN directive at the top of the file. " +
+			"If null, the \"name\" field of records will be null."),
+	},
+}
+
+var schemaContentModel = map[string]schema.Attribute{
+	"records": schema.ListNestedAttribute{
+		NestedObject: attributeObjectContentRecordModel,
+		Required:     true,
+		Description: ("The resource records to write to the zone file, in order. " +
+			"The records attribute of the zonefile_records data source is suitable as-is."),
+	},
+	"origin": schema.StringAttribute{
+		Optional: true,
+		Description: ("The origin to declare with an $ORIGIN directive at the top of the file. " +
+			"If set, the provider will write record names relative to the origin where possible."),
+	},
+	"ttl": schema.Int64Attribute{
+		Optional: true,
+		Description: ("The default TTL to declare with a $TTL directive at the top of the file. " +
+			"If not set, the provider will use the most common TTL among the records, " +
+			"and leave out the directive if there are no records."),
+	},
+	"content": schema.StringAttribute{
+		Computed:    true,
+		Description: "The rendered zone file.",
 	},
 }
 
 var schemaRecordsModel = lo.Assign(
 	schemaModelHead,
 	map[string]schema.Attribute{
+		"soa": schema.SingleNestedAttribute{
+			Attributes: schemaRecordsItemModel,
+			Computed:   true,
+			Description: ("The zone's SOA record, or null if the zone file has none. " +
+				"This is set whether or not \"managed_by_host\" is set."),
+		},
+		"apex_ns": schema.ListNestedAttribute{
+			NestedObject: attributeObjectRecordsItemModel,
+			Computed:     true,
+			Description: ("The NS records at the zone apex (see \"managed_by_host\"). " +
+				"This is set whether or not \"managed_by_host\" is set."),
+		},
 		"records": schema.ListNestedAttribute{
-			NestedObject: schema.NestedAttributeObject{Attributes: schemaRecordsItemModel},
+			NestedObject: attributeObjectRecordsItemModel,
 			Computed:     true,
 			Description:  "The zone file's resource records.",
 		},
+		"records_by_key": schema.MapNestedAttribute{
+			NestedObject: attributeObjectRecordsItemModel,
+			Computed:     true,
+			Description: ("The same records as \"records\", keyed by their \"key\" attribute. " +
+				"This is suitable for use with for_each."),
+		},
 	})
 
 var schemaRecordSetsModel = lo.Assign(
 	schemaModelHead,
 	map[string]schema.Attribute{
+		"ttl_conflict": schema.StringAttribute{
+			Optional: true,
+			Description: ("What to do if RRs in an RRSet have inconsistent TTLs, which RFC 2181 section 5.2 forbids. " +
+				"\"error\" fails with an error naming the conflicting lines. " +
+				"\"min\" uses the lowest TTL in the RRSet, as RFC 2181 recommends for clients that receive such an RRSet. " +
+				"\"max\" uses the highest TTL, and \"first\" uses the TTL of the first RR in the zone file. " +
+				"Each option other than \"error\" adds a warning for every RRSet it affects. Defaults to \"error\"."),
+		},
+		"soa": schema.SingleNestedAttribute{
+			Attributes: schemaRecordSetsItemModel,
+			Computed:   true,
+			Description: ("The RRSet of the zone's SOA record, or null if the zone file has none. " +
+				"This is set whether or not \"managed_by_host\" is set."),
+		},
+		"apex_ns": schema.SingleNestedAttribute{
+			Attributes: schemaRecordSetsItemModel,
+			Computed:   true,
+			Description: ("The RRSet of NS records at the zone apex (see \"managed_by_host\"), or null if there is none. " +
+				"This is set whether or not \"managed_by_host\" is set."),
+		},
 		"rrsets": schema.ListNestedAttribute{
-			NestedObject: schema.NestedAttributeObject{Attributes: schemaRecordSetsItemModel},
+			NestedObject: attributeObjectRecordSetsItemModel,
 			Computed:     true,
 			Description: ("The zone file's resource records grouped by name, class, and type. " +
 				"Unlike the records data source, this data source will fail with an error if " +
-				"any RRs in an RRSet have inconsistent TTLs (per RFC 2181 section 5.2)."),
+				"any RRs in an RRSet have inconsistent TTLs (per RFC 2181 section 5.2), unless \"ttl_conflict\" says otherwise."),
+		},
+		"rrsets_by_key": schema.MapNestedAttribute{
+			NestedObject: attributeObjectRecordSetsItemModel,
+			Computed:     true,
+			Description: ("The same RRSets as \"rrsets\", keyed by their \"key\" attribute. " +
+				"This is suitable for use with for_each."),
 		},
 	})
 
+// SOAModel represents the entire "zonefile_soa" data source.
+type SOAModel struct {
+	Content   types.String      `tfsdk:"content"`
+	Path      types.String      `tfsdk:"path"`
+	Origin    types.String      `tfsdk:"origin"`
+	Includes  map[string]string `tfsdk:"includes"`
+	MaxErrors types.Int64       `tfsdk:"max_errors"`
+
+	FQDN       types.String        `tfsdk:"fqdn"`
+	TTL        types.Int64         `tfsdk:"ttl"`
+	MName      types.String        `tfsdk:"mname"`
+	RName      types.String        `tfsdk:"rname"`
+	RNameEmail types.String        `tfsdk:"rname_email"`
+	Serial     types.Int64         `tfsdk:"serial"`
+	Refresh    types.Int64         `tfsdk:"refresh"`
+	Retry      types.Int64         `tfsdk:"retry"`
+	Expire     types.Int64         `tfsdk:"expire"`
+	Minimum    types.Int64         `tfsdk:"minimum"`
+	Source     *RecordsSourceModel `tfsdk:"source"`
+}
+
+var schemaSOAModel = lo.Assign(
+	lo.PickByKeys(schemaModelHead, []string{"content", "path", "origin", "includes", "max_errors"}),
+	schemaRecordsSOAModel,
+	map[string]schema.Attribute{
+		"origin": schema.StringAttribute{
+			Optional: true,
+			Description: ("The origin for relative record names in the file, " +
+				"equivalent to an $ORIGIN directive at the top of the file."),
+		},
+		"fqdn": schema.StringAttribute{
+			Computed:    true,
+			Description: "The fully qualified name of the zone apex, as the owner name of the SOA record.",
+		},
+		"ttl": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The TTL of the SOA record as an integer number of seconds.",
+		},
+		"source": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "Where the SOA record appears in the zone file.",
+			Attributes:  schemaRecordsSourceModel,
+		},
+	},
+)
+
 // RecordsItemModel represents each element in the "records" list of the
 // "zonefile_records" data source.
 type RecordsItemModel struct {
+	Key   types.String `tfsdk:"key"`
 	Name  types.String `tfsdk:"name"`
 	FQDN  types.String `tfsdk:"fqdn"`
 	Class types.String `tfsdk:"class"`
 	Type  types.String `tfsdk:"type"`
 	TTL   types.Int64  `tfsdk:"ttl"`
 
+	CanonicalFQDN           types.String `tfsdk:"canonical_fqdn"`
+	Origin                  types.String `tfsdk:"origin"`
+	NameRelativeToDirective types.String `tfsdk:"name_relative_to_directive"`
+	InZone                  types.Bool   `tfsdk:"in_zone"`
+
 	Data types.String     `tfsdk:"data"`
+	CAA  *RecordsCAAModel `tfsdk:"caa"`
 	MX   *RecordsMXModel  `tfsdk:"mx"`
+	SOA  *RecordsSOAModel `tfsdk:"soa"`
 	SRV  *RecordsSRVModel `tfsdk:"srv"`
 	TXT  types.String     `tfsdk:"txt"`
+
+	SSHFP  *RecordsSSHFPModel `tfsdk:"sshfp"`
+	TLSA   *RecordsTLSAModel  `tfsdk:"tlsa"`
+	SMIMEA *RecordsTLSAModel  `tfsdk:"smimea"`
+
+	Comment  types.String        `tfsdk:"comment"`
+	Metadata map[string]string   `tfsdk:"metadata"`
+	Source   *RecordsSourceModel `tfsdk:"source"`
 }
 
 // RecordSetsItemModel represents each element in the "rrsets" list of the
 // "zonefile_record_sets" data source.
 type RecordSetsItemModel struct {
+	Key   types.String `tfsdk:"key"`
 	Name  types.String `tfsdk:"name"`
 	FQDN  types.String `tfsdk:"fqdn"`
 	Class types.String `tfsdk:"class"`
 	Type  types.String `tfsdk:"type"`
 	TTL   types.Int64  `tfsdk:"ttl"`
 
+	CanonicalFQDN           types.String `tfsdk:"canonical_fqdn"`
+	Origin                  types.String `tfsdk:"origin"`
+	NameRelativeToDirective types.String `tfsdk:"name_relative_to_directive"`
+	InZone                  types.Bool   `tfsdk:"in_zone"`
+
 	Data types.List `tfsdk:"data"`
+	CAA  types.List `tfsdk:"caa"`
 	MX   types.List `tfsdk:"mx"`
+	SOA  types.List `tfsdk:"soa"`
 	SRV  types.List `tfsdk:"srv"`
 	TXT  types.List `tfsdk:"txt"`
+
+	SSHFP  types.List `tfsdk:"sshfp"`
+	TLSA   types.List `tfsdk:"tlsa"`
+	SMIMEA types.List `tfsdk:"smimea"`
+
+	Comments types.List        `tfsdk:"comments"`
+	Metadata map[string]string `tfsdk:"metadata"`
+	Sources  types.List        `tfsdk:"sources"`
 }
 
 var schemaItemModelHead = map[string]schema.Attribute{
 	"name": schema.StringAttribute{
 		Computed: true,
-		Description: ("The record's name relative to the origin in the data source configuration. " +
-			"This will be null for the zone apex (\"@\" in a zone file), or if the data source configuration " +
-			"does not specify an origin (even if the zone file includes an $ORIGIN directive)."),
+		Description: ("The record's name relative to the origin in the data source configuration, " +
+			"or the origin that \"origin_mode\" takes from the zone file. " +
+			"This will be null for the zone apex (\"@\" in a zone file), or if there is no such origin " +
+			"(even if the zone file includes an $ORIGIN directive)."),
 	},
 	"fqdn": schema.StringAttribute{
 		Computed: true,
 		Description: ("The record's fully qualified name. " +
-			"Unlike \"name\", this includes the effect of any $ORIGIN directives and ends with a trailing dot."),
+			"Unlike \"name\", this includes the effect of any $ORIGIN directives and ends with a trailing dot. " +
+			"This is spelled as written in the zone file (for an RRSet, by its first RR) unless \"normalize_names\" is set."),
+	},
+	"canonical_fqdn": schema.StringAttribute{
+		Computed: true,
+		Description: ("The record's fully qualified name in canonical form per RFC 4034 section 6.2, " +
+			"which is lowercase. Names that differ only in case are equivalent in DNS."),
+	},
+	"in_zone": schema.BoolAttribute{
+		Computed: true,
+		Description: ("Whether the record's name is at or below the effective origin of the data source, " +
+			"or null if there is no origin. See \"out_of_zone\"."),
+	},
+	"origin": schema.StringAttribute{
+		Computed: true,
+		Description: ("The origin in effect where the record appears in the zone file: " +
+			"that of the last $ORIGIN directive before it, or else the origin in the data source configuration. " +
+			"This is null if there is no origin at that point. " +
+			"For an RRSet, this is the origin of its first RR."),
+	},
+	"name_relative_to_directive": schema.StringAttribute{
+		Computed: true,
+		Description: ("The record's name relative to its \"origin\", as it might be written in the zone file. " +
+			"Unlike \"name\", this follows any $ORIGIN directives in the zone file. " +
+			"This will be null for the name that matches the origin, or if there is no origin."),
 	},
 	"class": schema.StringAttribute{
 		Computed:    true,
@@ -119,9 +484,19 @@ var schemaItemModelHead = map[string]schema.Attribute{
 	},
 }
 
+var attributeObjectRecordsItemModel = schema.NestedAttributeObject{Attributes: schemaRecordsItemModel}
+
 var schemaRecordsItemModel = lo.Assign(
 	schemaItemModelHead,
 	map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Computed: true,
+			Description: ("A key that identifies the record, formed from the key of its RRSet " +
+				"and a short hash of its data, like \"www/A/IN/5d3c0f2a\". " +
+				"The key stays the same as other records are added or removed, " +
+				"but changes with the record's data. " +
+				"Exact duplicates of a record get a numeric suffix (\"-2\", \"-3\", and so on) to keep keys unique."),
+		},
 		"data": schema.StringAttribute{
 			Computed: true,
 			Description: ("The record's data (RDATA) in its canonical presentation format " +
@@ -129,16 +504,42 @@ var schemaRecordsItemModel = lo.Assign(
 				"The provider parses the fields of select record types like MX and SRV, " +
 				"which is more robust than pulling them out of the RDATA string."),
 		},
+		"caa": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The parsed fields of a CAA record, or null if this isn't a CAA record.",
+			Attributes:  schemaRecordsCAAModel,
+		},
 		"mx": schema.SingleNestedAttribute{
 			Computed:    true,
 			Description: "The parsed fields of an MX record, or null if this isn't an MX record.",
 			Attributes:  schemaRecordsMXModel,
 		},
+		"soa": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The parsed fields of an SOA record, or null if this isn't an SOA record.",
+			Attributes:  schemaRecordsSOAModel,
+		},
 		"srv": schema.SingleNestedAttribute{
 			Computed:    true,
 			Description: "The parsed fields of an SRV record, or null if this isn't an SRV record.",
 			Attributes:  schemaRecordsSRVModel,
 		},
+		"sshfp": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The parsed fields of an SSHFP record, or null if this isn't an SSHFP record.",
+			Attributes:  schemaRecordsSSHFPModel,
+		},
+		"tlsa": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The parsed fields of a TLSA record, or null if this isn't a TLSA record.",
+			Attributes:  schemaRecordsTLSAModel,
+		},
+		"smimea": schema.SingleNestedAttribute{
+			Computed: true,
+			Description: ("The parsed fields of an SMIMEA record, which have the same form as those of a TLSA record, " +
+				"or null if this isn't an SMIMEA record."),
+			Attributes: schemaRecordsTLSAModel,
+		},
 		"txt": schema.StringAttribute{
 			Computed: true,
 			Description: ("The concatenation of multiple strings in the TXT record, or null if this isn't a TXT record. " +
@@ -146,12 +547,43 @@ var schemaRecordsItemModel = lo.Assign(
 				"but a single TXT RR can define multiple logically concatenated strings. " +
 				"Your provider may require special handling if this value is longer than 255 characters."),
 		},
+		"comment": schema.StringAttribute{
+			Computed: true,
+			Description: ("The text of the comment after the record in the zone file, without the leading semicolon, " +
+				"or null if the record has no comment. " +
+				"If a record spans multiple lines with comments on more than one, " +
+				"the comments are joined with spaces (and keep their semicolons after the first). " +
+				"Records generated by $GENERATE have no comments."),
+		},
+		"metadata": schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: ("Annotations from the record's comment, which follow an \"@tf\" marker as key=value pairs " +
+				"separated by blanks, like \"; @tf proxied=true owner=payments\". " +
+				"Values may be quoted, with the same escapes as a Go string literal. " +
+				"Other text in the comment before the marker is ignored. " +
+				"This is an empty map if the record has no annotations, or if they are malformed (which the data sources report with a warning)."),
+		},
+		"source": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "Where the record appears in the zone file.",
+			Attributes:  schemaRecordsSourceModel,
+		},
 	},
 )
 
+var attributeObjectRecordSetsItemModel = schema.NestedAttributeObject{Attributes: schemaRecordSetsItemModel}
+
 var schemaRecordSetsItemModel = lo.Assign(
 	schemaItemModelHead,
 	map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Computed: true,
+			Description: ("A key that identifies the RRSet by its name, type, and class, like \"www/A/IN\". " +
+				"The name is relative to the origin in the data source configuration (\"@\" for the zone apex) " +
+				"where possible, and fully qualified otherwise. " +
+				"The key stays the same as the RRSet's data changes, so that for_each can update it in place."),
+		},
 		"data": schema.ListAttribute{
 			ElementType: types.StringType,
 			Computed:    true,
@@ -160,16 +592,42 @@ var schemaRecordSetsItemModel = lo.Assign(
 				"The provider parses the fields of select record types like MX and SRV, " +
 				"which is more robust than pulling them out of the RDATA strings."),
 		},
+		"caa": schema.ListNestedAttribute{
+			NestedObject: attributeObjectCAAModel,
+			Computed:     true,
+			Description:  "The parsed fields of CAA records, or null if this isn't a CAA RRSet.",
+		},
 		"mx": schema.ListNestedAttribute{
 			NestedObject: attributeObjectMXModel,
 			Computed:     true,
 			Description:  "The parsed fields of MX records, or null if this isn't an MX RRSet.",
 		},
+		"soa": schema.ListNestedAttribute{
+			NestedObject: attributeObjectSOAModel,
+			Computed:     true,
+			Description:  "The parsed fields of SOA records, or null if this isn't an SOA RRSet.",
+		},
 		"srv": schema.ListNestedAttribute{
 			NestedObject: attributeObjectSRVModel,
 			Computed:     true,
 			Description:  "The parsed fields of SRV records, or null if this isn't an SRV RRSet.",
 		},
+		"sshfp": schema.ListNestedAttribute{
+			NestedObject: attributeObjectSSHFPModel,
+			Computed:     true,
+			Description:  "The parsed fields of SSHFP records, or null if this isn't an SSHFP RRSet.",
+		},
+		"tlsa": schema.ListNestedAttribute{
+			NestedObject: attributeObjectTLSAModel,
+			Computed:     true,
+			Description:  "The parsed fields of TLSA records, or null if this isn't a TLSA RRSet.",
+		},
+		"smimea": schema.ListNestedAttribute{
+			NestedObject: attributeObjectTLSAModel,
+			Computed:     true,
+			Description: ("The parsed fields of SMIMEA records, which have the same form as those of TLSA records, " +
+				"or null if this isn't an SMIMEA RRSet."),
+		},
 		"txt": schema.ListAttribute{
 			ElementType: types.StringType,
 			Computed:    true,
@@ -178,6 +636,26 @@ var schemaRecordSetsItemModel = lo.Assign(
 				"but a single TXT RR can define multiple logically concatenated strings. " +
 				"Your provider may require special handling if any of these values are longer than 255 characters."),
 		},
+		"comments": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: ("The text of the comment after each RR in the zone file, without the leading semicolon, " +
+				"or null for RRs that have no comment. " +
+				"See the \"comment\" attribute of the zonefile_records data source for details."),
+		},
+		"metadata": schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: ("The combined annotations from the comments of every RR in the RRSet. " +
+				"See the \"metadata\" attribute of the zonefile_records data source for the syntax. " +
+				"RRs in an RRSet may annotate different keys, but the data source will fail with an error " +
+				"if they give different values to the same key."),
+		},
+		"sources": schema.ListNestedAttribute{
+			NestedObject: attributeObjectSourceModel,
+			Computed:     true,
+			Description:  "Where each RR in the RRSet appears in the zone file.",
+		},
 	},
 )
 
@@ -185,7 +663,11 @@ func nameModelValue(fqdn, origin string) types.String {
 	if origin == "" {
 		return types.StringNull()
 	}
-	name := strings.TrimSuffix(fqdn, dns.Fqdn(origin))
+	origin = dns.Fqdn(origin)
+	name := fqdn
+	if dns.IsSubDomain(origin, name) {
+		name = name[:len(name)-len(origin)]
+	}
 	name = strings.TrimSuffix(name, ".")
 	if name == "" {
 		return types.StringNull()
@@ -193,6 +675,20 @@ func nameModelValue(fqdn, origin string) types.String {
 	return types.StringValue(name)
 }
 
+func inZoneModelValue(fqdn, origin string) types.Bool {
+	if origin == "" {
+		return types.BoolNull()
+	}
+	return types.BoolValue(dns.IsSubDomain(origin, fqdn))
+}
+
+func originModelValue(origin string) types.String {
+	if origin == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(origin)
+}
+
 func rdataModelValue(rr dns.RR) types.String {
 	return types.StringValue(strings.TrimPrefix(rr.String(), rr.Header().String()))
 }
@@ -204,6 +700,151 @@ func txtModelValue(rr dns.RR) types.String {
 	return types.StringNull()
 }
 
+func commentModelValue(comment string) types.String {
+	if comment == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(strings.TrimSpace(strings.TrimPrefix(comment, ";")))
+}
+
+// RecordsSourceModel represents the location of a record in a zone file
+// exposed through either data source.
+type RecordsSourceModel struct {
+	File         types.String `tfsdk:"file"`
+	Line         types.Int64  `tfsdk:"line"`
+	Generated    types.Bool   `tfsdk:"generated"`
+	GenerateLine types.Int64  `tfsdk:"generate_line"`
+}
+
+var (
+	attributeObjectSourceModel = schema.NestedAttributeObject{Attributes: schemaRecordsSourceModel}
+	schemaRecordsSourceModel   = map[string]schema.Attribute{
+		"file": schema.StringAttribute{
+			Computed: true,
+			Description: ("The file containing the record: the path of a file read through $INCLUDE, " +
+				"or of the main zone file when using \"path\". " +
+				"This is null for records in the main zone file when using \"content\"."),
+		},
+		"line": schema.Int64Attribute{
+			Computed: true,
+			Description: ("The line of the file where the record starts, counting from 1. " +
+				"For records generated by $GENERATE, this is the line of the directive."),
+		},
+		"generated": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether a $GENERATE directive produced the record.",
+		},
+		"generate_line": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The line of the $GENERATE directive that produced the record, or null if \"generated\" is false.",
+		},
+	}
+)
+
+func sourceModelValue(rr zoneRR) *RecordsSourceModel {
+	if rr.File == nil {
+		return nil
+	}
+	source := &RecordsSourceModel{
+		File:         types.StringNull(),
+		Line:         types.Int64Value(int64(rr.Line)),
+		Generated:    types.BoolValue(rr.Generated),
+		GenerateLine: types.Int64Null(),
+	}
+	if rr.File.Name != "" {
+		source.File = types.StringValue(rr.File.Name)
+	}
+	if rr.Generated {
+		source.GenerateLine = types.Int64Value(int64(rr.Line))
+	}
+	return source
+}
+
+// RecordsCAAModel represents the parsed fields of CAA records exposed through
+// either data source.
+type RecordsCAAModel struct {
+	Flag       types.Int64       `tfsdk:"flag"`
+	Tag        types.String      `tfsdk:"tag"`
+	Value      types.String      `tfsdk:"value"`
+	Critical   types.Bool        `tfsdk:"critical"`
+	Issuer     types.String      `tfsdk:"issuer"`
+	Parameters map[string]string `tfsdk:"parameters"`
+}
+
+var (
+	attributeObjectCAAModel = schema.NestedAttributeObject{Attributes: schemaRecordsCAAModel}
+	schemaRecordsCAAModel   = map[string]schema.Attribute{
+		"flag": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The flags of the CAA record as an integer from 0 to 255.",
+		},
+		"tag": schema.StringAttribute{
+			Computed:    true,
+			Description: "The property tag of the CAA record, like \"issue\", \"issuewild\", or \"iodef\".",
+		},
+		"value": schema.StringAttribute{
+			Computed:    true,
+			Description: "The property value of the CAA record, without quotes.",
+		},
+		"critical": schema.BoolAttribute{
+			Computed: true,
+			Description: ("Whether the issuer critical flag (128) is set, " +
+				"which tells certificate authorities that don't understand the tag not to issue certificates."),
+		},
+		"issuer": schema.StringAttribute{
+			Computed: true,
+			Description: ("For an \"issue\" or \"issuewild\" property, the domain name of the certificate authority " +
+				"that may issue certificates, per RFC 8659 section 4.2. " +
+				"This is null for other properties, or if the value names no issuer (like \";\", which forbids issuance)."),
+		},
+		"parameters": schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: ("For an \"issue\" or \"issuewild\" property, the key=value parameters after the issuer, " +
+				"like \"accounturi\" and \"validationmethods\" from RFC 8657. " +
+				"This is null for other properties."),
+		},
+	}
+)
+
+func caaModelValue(rr dns.RR) *RecordsCAAModel {
+	caa, ok := rr.(*dns.CAA)
+	if !ok {
+		return nil
+	}
+	model := &RecordsCAAModel{
+		Flag:     types.Int64Value(int64(caa.Flag)),
+		Tag:      types.StringValue(caa.Tag),
+		Value:    types.StringValue(caa.Value),
+		Critical: types.BoolValue(caa.Flag&128 != 0),
+		Issuer:   types.StringNull(),
+	}
+	if tag := strings.ToLower(caa.Tag); tag == "issue" || tag == "issuewild" {
+		issuer, parameters := parseCAAIssuerValue(caa.Value)
+		if issuer != "" {
+			model.Issuer = types.StringValue(issuer)
+		}
+		model.Parameters = parameters
+	}
+	return model
+}
+
+// parseCAAIssuerValue parses the value of an "issue" or "issuewild" CAA
+// property per RFC 8659 section 4.2: an optional issuer domain name, then any
+// number of key=value parameters, each preceded by a semicolon. The parameters
+// are never nil.
+func parseCAAIssuerValue(value string) (string, map[string]string) {
+	issuer, rest, _ := strings.Cut(value, ";")
+	parameters := make(map[string]string)
+	for _, param := range strings.Split(rest, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if key != "" {
+			parameters[key] = value
+		}
+	}
+	return strings.TrimSpace(issuer), parameters
+}
+
 // RecordsMXModel represents the parsed fields of MX records exposed through
 // either data source.
 type RecordsMXModel struct {
@@ -235,6 +876,93 @@ func mxModelValue(rr dns.RR) *RecordsMXModel {
 	return nil
 }
 
+// RecordsSOAModel represents the parsed fields of SOA records exposed through
+// either data source.
+type RecordsSOAModel struct {
+	MName      types.String `tfsdk:"mname"`
+	RName      types.String `tfsdk:"rname"`
+	RNameEmail types.String `tfsdk:"rname_email"`
+	Serial     types.Int64  `tfsdk:"serial"`
+	Refresh    types.Int64  `tfsdk:"refresh"`
+	Retry      types.Int64  `tfsdk:"retry"`
+	Expire     types.Int64  `tfsdk:"expire"`
+	Minimum    types.Int64  `tfsdk:"minimum"`
+}
+
+var (
+	attributeObjectSOAModel = schema.NestedAttributeObject{Attributes: schemaRecordsSOAModel}
+	schemaRecordsSOAModel   = map[string]schema.Attribute{
+		"mname": schema.StringAttribute{
+			Computed:    true,
+			Description: "The domain name of the primary name server for the zone.",
+		},
+		"rname": schema.StringAttribute{
+			Computed: true,
+			Description: ("The mailbox of the person responsible for the zone, as a domain name " +
+				"in which the first label is the local part of the address."),
+		},
+		"rname_email": schema.StringAttribute{
+			Computed: true,
+			Description: ("The mailbox in \"rname\" as an email address, like \"hostmaster@example.com\" " +
+				"for \"hostmaster.example.com.\". Escaped dots in the first label of \"rname\" " +
+				"are part of the local part of the address."),
+		},
+		"serial": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The version number of the zone, which secondary name servers compare to decide whether to transfer it.",
+		},
+		"refresh": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of seconds before secondary name servers should check for a new serial.",
+		},
+		"retry": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of seconds before secondary name servers should retry a failed refresh.",
+		},
+		"expire": schema.Int64Attribute{
+			Computed: true,
+			Description: ("The number of seconds after which secondary name servers should stop answering for the zone " +
+				"if they can't refresh it."),
+		},
+		"minimum": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The TTL for negative responses from the zone, in seconds, per RFC 2308.",
+		},
+	}
+)
+
+func soaModelValue(rr dns.RR) *RecordsSOAModel {
+	if soa, ok := rr.(*dns.SOA); ok {
+		return &RecordsSOAModel{
+			MName:      types.StringValue(soa.Ns),
+			RName:      types.StringValue(soa.Mbox),
+			RNameEmail: types.StringValue(mailboxEmail(soa.Mbox)),
+			Serial:     types.Int64Value(int64(soa.Serial)),
+			Refresh:    types.Int64Value(int64(soa.Refresh)),
+			Retry:      types.Int64Value(int64(soa.Retry)),
+			Expire:     types.Int64Value(int64(soa.Expire)),
+			Minimum:    types.Int64Value(int64(soa.Minttl)),
+		}
+	}
+	return nil
+}
+
+// mailboxEmail converts a mailbox in the form of a domain name, like the RNAME
+// of an SOA record, to an email address. The first unescaped dot separates
+// the local part of the address from its domain.
+func mailboxEmail(mbox string) string {
+	mbox = strings.TrimSuffix(mbox, ".")
+	for i := 0; i < len(mbox); i++ {
+		switch mbox[i] {
+		case '\\':
+			i++
+		case '.':
+			return strings.ReplaceAll(mbox[:i], `\.`, ".") + "@" + mbox[i+1:]
+		}
+	}
+	return strings.ReplaceAll(mbox, `\.`, ".")
+}
+
 // RecordsSRVModel represents the parsed fields of SRV records exposed through
 // either data source.
 type RecordsSRVModel struct {
@@ -277,3 +1005,193 @@ func srvModelValue(rr dns.RR) *RecordsSRVModel {
 	}
 	return nil
 }
+
+// ContentRecordModel represents each element in the "records" list of the
+// "zonefile_content" data source, along with the elements of the equivalent
+// parameter to the "format" function.
+type ContentRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	FQDN  types.String `tfsdk:"fqdn"`
+	Class types.String `tfsdk:"class"`
+	Type  types.String `tfsdk:"type"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Data  types.String `tfsdk:"data"`
+}
+
+var (
+	attributeObjectContentRecordModel = schema.NestedAttributeObject{Attributes: schemaContentRecordModel}
+	schemaContentRecordModel          = map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Optional: true,
+			Description: ("The record's name relative to the origin. " +
+				"Ignored if \"fqdn\" is set. If neither is set, the record is placed at the zone apex."),
+		},
+		"fqdn": schema.StringAttribute{
+			Optional:    true,
+			Description: "The record's fully qualified name.",
+		},
+		"class": schema.StringAttribute{
+			Optional:    true,
+			Description: "The record's class. Defaults to IN (Internet).",
+		},
+		"type": schema.StringAttribute{
+			Required:    true,
+			Description: "The record's type: A, AAAA, CNAME, TXT, etc.",
+		},
+		"ttl": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The record's TTL as an integer number of seconds. Defaults to the zone file's default TTL.",
+		},
+		"data": schema.StringAttribute{
+			Required: true,
+			Description: ("The record's data (RDATA) in presentation format. " +
+				"Names relative to the origin are permitted."),
+		},
+	}
+)
+
+// RecordsSSHFPModel represents the parsed fields of SSHFP records exposed
+// through either data source.
+type RecordsSSHFPModel struct {
+	Algorithm     types.Int64  `tfsdk:"algorithm"`
+	AlgorithmName types.String `tfsdk:"algorithm_name"`
+	FPType        types.Int64  `tfsdk:"fp_type"`
+	FPTypeName    types.String `tfsdk:"fp_type_name"`
+	Fingerprint   types.String `tfsdk:"fingerprint"`
+}
+
+var (
+	attributeObjectSSHFPModel = schema.NestedAttributeObject{Attributes: schemaRecordsSSHFPModel}
+	schemaRecordsSSHFPModel   = map[string]schema.Attribute{
+		"algorithm": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of the algorithm of the SSH host key.",
+		},
+		"algorithm_name": schema.StringAttribute{
+			Computed: true,
+			Description: ("The name of the algorithm of the SSH host key: " +
+				"\"RSA\", \"DSA\", \"ECDSA\", \"Ed25519\", or \"Ed448\", or null for an unknown algorithm."),
+		},
+		"fp_type": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of the hash algorithm of the fingerprint.",
+		},
+		"fp_type_name": schema.StringAttribute{
+			Computed: true,
+			Description: ("The name of the hash algorithm of the fingerprint: " +
+				"\"SHA-1\" or \"SHA-256\", or null for an unknown algorithm."),
+		},
+		"fingerprint": schema.StringAttribute{
+			Computed:    true,
+			Description: "The fingerprint of the SSH host key in uppercase hexadecimal.",
+		},
+	}
+)
+
+var (
+	sshfpAlgorithmNames = map[uint8]string{1: "RSA", 2: "DSA", 3: "ECDSA", 4: "Ed25519", 6: "Ed448"}
+	sshfpTypeNames      = map[uint8]string{1: "SHA-1", 2: "SHA-256"}
+)
+
+func sshfpModelValue(rr dns.RR) *RecordsSSHFPModel {
+	if sshfp, ok := rr.(*dns.SSHFP); ok {
+		return &RecordsSSHFPModel{
+			Algorithm:     types.Int64Value(int64(sshfp.Algorithm)),
+			AlgorithmName: enumNameModelValue(sshfpAlgorithmNames, sshfp.Algorithm),
+			FPType:        types.Int64Value(int64(sshfp.Type)),
+			FPTypeName:    enumNameModelValue(sshfpTypeNames, sshfp.Type),
+			Fingerprint:   types.StringValue(strings.ToUpper(sshfp.FingerPrint)),
+		}
+	}
+	return nil
+}
+
+// RecordsTLSAModel represents the parsed fields of TLSA and SMIMEA records,
+// which share the same RDATA format, exposed through either data source.
+type RecordsTLSAModel struct {
+	Usage            types.Int64  `tfsdk:"usage"`
+	UsageName        types.String `tfsdk:"usage_name"`
+	Selector         types.Int64  `tfsdk:"selector"`
+	SelectorName     types.String `tfsdk:"selector_name"`
+	MatchingType     types.Int64  `tfsdk:"matching_type"`
+	MatchingTypeName types.String `tfsdk:"matching_type_name"`
+	Certificate      types.String `tfsdk:"certificate"`
+}
+
+var (
+	attributeObjectTLSAModel = schema.NestedAttributeObject{Attributes: schemaRecordsTLSAModel}
+	schemaRecordsTLSAModel   = map[string]schema.Attribute{
+		"usage": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The certificate usage, which says how to verify the certificate.",
+		},
+		"usage_name": schema.StringAttribute{
+			Computed: true,
+			Description: ("The mnemonic for the certificate usage from RFC 7218: " +
+				"\"PKIX-TA\", \"PKIX-EE\", \"DANE-TA\", \"DANE-EE\", or \"PrivCert\", or null for an unknown usage."),
+		},
+		"selector": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The selector, which says which part of the certificate to match.",
+		},
+		"selector_name": schema.StringAttribute{
+			Computed: true,
+			Description: ("The mnemonic for the selector from RFC 7218: " +
+				"\"Cert\", \"SPKI\", or \"PrivSel\", or null for an unknown selector."),
+		},
+		"matching_type": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The matching type, which says how to compare the certificate association data.",
+		},
+		"matching_type_name": schema.StringAttribute{
+			Computed: true,
+			Description: ("The mnemonic for the matching type from RFC 7218: " +
+				"\"Full\", \"SHA2-256\", \"SHA2-512\", or \"PrivMatch\", or null for an unknown matching type."),
+		},
+		"certificate": schema.StringAttribute{
+			Computed:    true,
+			Description: "The certificate association data in uppercase hexadecimal.",
+		},
+	}
+)
+
+var (
+	tlsaUsageNames        = map[uint8]string{0: "PKIX-TA", 1: "PKIX-EE", 2: "DANE-TA", 3: "DANE-EE", 255: "PrivCert"}
+	tlsaSelectorNames     = map[uint8]string{0: "Cert", 1: "SPKI", 255: "PrivSel"}
+	tlsaMatchingTypeNames = map[uint8]string{0: "Full", 1: "SHA2-256", 2: "SHA2-512", 255: "PrivMatch"}
+)
+
+func tlsaModelValue(rr dns.RR) *RecordsTLSAModel {
+	if tlsa, ok := rr.(*dns.TLSA); ok {
+		return newTLSAModel(tlsa.Usage, tlsa.Selector, tlsa.MatchingType, tlsa.Certificate)
+	}
+	return nil
+}
+
+func smimeaModelValue(rr dns.RR) *RecordsTLSAModel {
+	if smimea, ok := rr.(*dns.SMIMEA); ok {
+		return newTLSAModel(smimea.Usage, smimea.Selector, smimea.MatchingType, smimea.Certificate)
+	}
+	return nil
+}
+
+func newTLSAModel(usage, selector, matchingType uint8, certificate string) *RecordsTLSAModel {
+	return &RecordsTLSAModel{
+		Usage:            types.Int64Value(int64(usage)),
+		UsageName:        enumNameModelValue(tlsaUsageNames, usage),
+		Selector:         types.Int64Value(int64(selector)),
+		SelectorName:     enumNameModelValue(tlsaSelectorNames, selector),
+		MatchingType:     types.Int64Value(int64(matchingType)),
+		MatchingTypeName: enumNameModelValue(tlsaMatchingTypeNames, matchingType),
+		Certificate:      types.StringValue(strings.ToUpper(certificate)),
+	}
+}
+
+// enumNameModelValue returns the name of a value from an IANA registry, or
+// null if the value isn't in names.
+func enumNameModelValue(names map[uint8]string, value uint8) types.String {
+	if name, ok := names[value]; ok {
+		return types.StringValue(name)
+	}
+	return types.StringNull()
+}
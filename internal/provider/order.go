@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Values of the order attribute.
+const (
+	orderFile      = "file"
+	orderCanonical = "canonical"
+	orderNameType  = "name_type"
+)
+
+// sortRRs sorts RRs in place per the order attribute. Ties keep their order in
+// the zone file.
+func sortRRs(rrs []zoneRR, order string) {
+	if order != orderCanonical && order != orderNameType {
+		return
+	}
+	slices.SortStableFunc(rrs, func(a, b zoneRR) int {
+		c := compareHeaders(order, a.RR.Header(), b.RR.Header())
+		if c == 0 && order == orderCanonical {
+			c = bytes.Compare(canonicalRdata(a.RR), canonicalRdata(b.RR))
+		}
+		return c
+	})
+}
+
+// sortRRSets sorts RRSets in place per the order attribute, along with the
+// RRs in each set. Since groupRRs has already taken the header of each set
+// from its first RR in the zone file, sorting doesn't change which RR that is.
+func sortRRSets(rrSets []rrSet, order string) {
+	if order != orderCanonical && order != orderNameType {
+		return
+	}
+	slices.SortStableFunc(rrSets, func(a, b rrSet) int {
+		return compareHeaders(order, &a.Hdr, &b.Hdr)
+	})
+	for _, set := range rrSets {
+		sortRRs(set.RRs, order)
+	}
+}
+
+// compareHeaders orders RR headers by name, class, and type per the order
+// attribute.
+func compareHeaders(order string, a, b *dns.RR_Header) int {
+	switch order {
+	case orderCanonical:
+		return cmp.Or(
+			compareLabels(canonicalLabels(a.Name), canonicalLabels(b.Name)),
+			cmp.Compare(a.Class, b.Class),
+			cmp.Compare(a.Rrtype, b.Rrtype),
+		)
+	case orderNameType:
+		return cmp.Or(
+			strings.Compare(dns.CanonicalName(a.Name), dns.CanonicalName(b.Name)),
+			strings.Compare(dns.TypeToString[a.Rrtype], dns.TypeToString[b.Rrtype]),
+			strings.Compare(dns.ClassToString[a.Class], dns.ClassToString[b.Class]),
+		)
+	}
+	return 0
+}
+
+// canonicalLabels returns the labels of a name in canonical form, as octets
+// without escapes, from the rightmost label to the leftmost.
+func canonicalLabels(name string) [][]byte {
+	buf := make([]byte, 256)
+	n, err := dns.PackDomainName(dns.CanonicalName(name), buf, 0, nil, false)
+	if err != nil {
+		return nil
+	}
+	var labels [][]byte
+	for i := 0; i < n && buf[i] > 0; i += int(buf[i]) + 1 {
+		labels = append(labels, buf[i+1:i+1+int(buf[i])])
+	}
+	slices.Reverse(labels)
+	return labels
+}
+
+// compareLabels orders names by their canonical labels per RFC 4034 section
+// 6.1, which sorts names in the same zone together with parents before their
+// children.
+func compareLabels(a, b [][]byte) int {
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if c := bytes.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// canonicalRdata returns the RDATA of an RR in the canonical wire format of
+// RFC 4034 section 6.2, which orders the RRs of an RRSet per section 6.3.
+func canonicalRdata(rr dns.RR) []byte {
+	rr = dns.Copy(rr)
+	canonicalizeRdataNames(rr)
+	buf := make([]byte, dns.Len(rr)+1)
+	off, err := dns.PackRR(rr, buf, 0, nil, false)
+	if err != nil {
+		return nil
+	}
+	rdlength := int(rr.Header().Rdlength)
+	return buf[off-rdlength : off]
+}
+
+// canonicalizeRdataNames converts the domain names in the RDATA of an RR to
+// lowercase, for the types that RFC 4034 section 6.2 lists (less HINFO, per
+// RFC 6840 section 5.1).
+func canonicalizeRdataNames(rr dns.RR) {
+	switch rr := rr.(type) {
+	case *dns.NS:
+		rr.Ns = dns.CanonicalName(rr.Ns)
+	case *dns.MD:
+		rr.Md = dns.CanonicalName(rr.Md)
+	case *dns.MF:
+		rr.Mf = dns.CanonicalName(rr.Mf)
+	case *dns.CNAME:
+		rr.Target = dns.CanonicalName(rr.Target)
+	case *dns.SOA:
+		rr.Ns = dns.CanonicalName(rr.Ns)
+		rr.Mbox = dns.CanonicalName(rr.Mbox)
+	case *dns.MB:
+		rr.Mb = dns.CanonicalName(rr.Mb)
+	case *dns.MG:
+		rr.Mg = dns.CanonicalName(rr.Mg)
+	case *dns.MR:
+		rr.Mr = dns.CanonicalName(rr.Mr)
+	case *dns.PTR:
+		rr.Ptr = dns.CanonicalName(rr.Ptr)
+	case *dns.MINFO:
+		rr.Rmail = dns.CanonicalName(rr.Rmail)
+		rr.Email = dns.CanonicalName(rr.Email)
+	case *dns.MX:
+		rr.Mx = dns.CanonicalName(rr.Mx)
+	case *dns.RP:
+		rr.Mbox = dns.CanonicalName(rr.Mbox)
+		rr.Txt = dns.CanonicalName(rr.Txt)
+	case *dns.AFSDB:
+		rr.Hostname = dns.CanonicalName(rr.Hostname)
+	case *dns.RT:
+		rr.Host = dns.CanonicalName(rr.Host)
+	case *dns.SIG:
+		rr.SignerName = dns.CanonicalName(rr.SignerName)
+	case *dns.PX:
+		rr.Map822 = dns.CanonicalName(rr.Map822)
+		rr.Mapx400 = dns.CanonicalName(rr.Mapx400)
+	case *dns.NAPTR:
+		rr.Replacement = dns.CanonicalName(rr.Replacement)
+	case *dns.KX:
+		rr.Exchanger = dns.CanonicalName(rr.Exchanger)
+	case *dns.SRV:
+		rr.Target = dns.CanonicalName(rr.Target)
+	case *dns.DNAME:
+		rr.Target = dns.CanonicalName(rr.Target)
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+)
+
+var _ function.Function = &ParseRRFunction{}
+
+type ParseRRFunction struct{}
+
+func NewParseRRFunction() function.Function {
+	return &ParseRRFunction{}
+}
+
+func (f *ParseRRFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_rr"
+}
+
+func (f *ParseRRFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a single resource record in presentation format.",
+		Description: ("Parse a single resource record in presentation format (that is, how you might write it in a zone file), " +
+			"with the same structure as each element of the records attribute of the zonefile_records data source."),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "rr",
+				Description: "The resource record as a string, like a single line of a zone file.",
+			},
+			function.StringParameter{
+				Name:           "origin",
+				AllowNullValue: true,
+				Description: ("The origin for relative names in the record. " +
+					"If null, the \"name\" field of the result will be null, and the record must use fully qualified names."),
+			},
+			function.Int64Parameter{
+				Name:           "ttl",
+				AllowNullValue: true,
+				Description: ("The default TTL for the record as an integer number of seconds, " +
+					"equivalent to a $TTL directive in a zone file. If null, the record must specify its own TTL."),
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: attributeObjectRecordsItemModel.Type().(types.ObjectType).AttrTypes},
+	}
+}
+
+func (f *ParseRRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content, origin types.String
+	var ttl types.Int64
+	resp.Error = req.Arguments.Get(ctx, &content, &origin, &ttl)
+	if resp.Error != nil {
+		return
+	}
+
+	var defaultTTL *uint32
+	if !ttl.IsNull() {
+		v, err := ttlValue(ttl.ValueInt64())
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, err.Error())
+			return
+		}
+		defaultTTL = &v
+	}
+
+	rr, err := readRR(content.ValueString(), origin.ValueString(), defaultTTL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid resource record: "+err.Error())
+		return
+	}
+
+	// The origin argument acts like an $ORIGIN directive, as in the records
+	// function.
+	zrr := zoneRR{RR: rr, Metadata: map[string]string{}}
+	if origin.ValueString() != "" {
+		zrr.Origin = dns.Fqdn(origin.ValueString())
+	}
+	resp.Error = resp.Result.Set(ctx, recordsItemModels([]zoneRR{zrr}, origin.ValueString())[0])
+}
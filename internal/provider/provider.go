@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &ZonefileProvider{}
@@ -19,7 +20,9 @@ type ZonefileProvider struct {
 	version string
 }
 
-type ZonefileProviderModel struct{}
+type ZonefileProviderModel struct {
+	IncludeRoot types.String `tfsdk:"include_root"`
+}
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -35,7 +38,17 @@ func (p *ZonefileProvider) Metadata(ctx context.Context, req provider.MetadataRe
 }
 
 func (p *ZonefileProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"include_root": schema.StringAttribute{
+				Optional: true,
+				Description: ("A directory that $INCLUDE directives can read from when data sources read zone files by path. " +
+					"$INCLUDE targets are resolved relative to the including file, " +
+					"and must not resolve to anything outside of this directory (even through symbolic links). " +
+					"If not set, $INCLUDE directives are not permitted in zone files read by path."),
+			},
+		},
+	}
 }
 
 func (p *ZonefileProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -44,12 +57,16 @@ func (p *ZonefileProvider) Configure(ctx context.Context, req provider.Configure
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.DataSourceData = &data
 }
 
 func (p *ZonefileProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewRecordsDataSource,
 		NewRecordSetsDataSource,
+		NewContentDataSource,
+		NewSOADataSource,
 	}
 }
 
@@ -58,5 +75,10 @@ func (p *ZonefileProvider) Resources(ctx context.Context) []func() resource.Reso
 }
 
 func (p *ZonefileProvider) Functions(ctx context.Context) []func() function.Function {
-	return nil
+	return []func() function.Function{
+		NewRecordsFunction,
+		NewRecordSetsFunction,
+		NewFormatFunction,
+		NewParseRRFunction,
+	}
 }
@@ -2,11 +2,15 @@ package provider
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const (
@@ -23,20 +27,35 @@ srv 1800 IN SRV 2 1 443 app2.app.test.
 
 txt 300 IN TXT "first"
 txt 300 IN TXT "and" " " "second"
+`
+	testFormatted = `$ORIGIN main.test.
+$TTL 1800
+
+@        IN A   10.100.0.10
+@        IN A   10.200.0.20
+@   3600 IN MX  10 mx1.mail.test.
+@   3600 IN MX  20 mx2.mail.test.
+srv      IN SRV 1 1 443 app1.app.test.
+srv      IN SRV 2 1 443 app2.app.test.
+txt 300  IN TXT "first"
+txt 300  IN TXT "and" " " "second"
 `
 )
 
 var (
 	eq   = resource.TestCheckResourceAttr
 	null = resource.TestCheckNoResourceAttr
+	out  = resource.TestCheckOutput
 )
 
+var testProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"zonefile": providerserver.NewProtocol6WithError(New("test")()),
+}
+
 func TestZonefileDataSources(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
-			"zonefile": providerserver.NewProtocol6WithError(New("test")()),
-		},
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -177,3 +196,1366 @@ func TestZonefileDataSources(t *testing.T) {
 		},
 	})
 }
+
+func TestZonefileErrors(t *testing.T) {
+	const brokenZonefile = `
+a 3600 IN A 10.100.0.10
+b 3600 IN A not-an-ip
+c 3600 IN MX (
+	10
+	mx..bad )
+d 3600 IN A 10.100.0.20
+e 3600 IN AAAA not-an-ip
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, brokenZonefile),
+				ExpectError: regexp.MustCompile(`(?s)line 3, column 13: bad A A: "not-an-ip".*` +
+					`line 6, column 2: bad MX Mx: "mx..bad".*` +
+					`line 8, column 16: bad AAAA AAAA: "not-an-ip"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, brokenZonefile),
+				ExpectError: regexp.MustCompile(`(?s)3 \| b 3600 IN A not-an-ip\s.*\| +\^{9}\s.*4 \| c 3600 IN MX \(`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = "a 60 IN A 10.100.0.10\nb 60 IN A 10.100.0.11\na 120 IN A 10.100.0.12\n"
+					}`,
+					testOrigin),
+				ExpectError: regexp.MustCompile(`(?s)line 3: inconsistent TTLs.*\(60\s+at\s+line\s+1\s+vs.\s+120\s+at\s+line\s+3\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin     = %q
+						content    = %q
+						max_errors = 2
+					}`,
+					testOrigin, brokenZonefile),
+				ExpectError: regexp.MustCompile(`(?s)line 3, column 13: bad A A: "not-an-ip".*line 6, column 2: bad MX Mx: "mx..bad"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin     = %q
+						content    = %q
+						max_errors = 0
+					}`,
+					testOrigin, brokenZonefile),
+				ExpectError: regexp.MustCompile(`max_errors must be at least 1`),
+			},
+		},
+	})
+}
+
+func TestZonefileIncludes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "zonefile_records" "main" {
+						origin   = "main.test."
+						content  = "@ 3600 IN A 10.100.0.10\n$INCLUDE ./mail.zone\n$INCLUDE mail.zone sub.main.test.\n"
+						includes = {
+							"mail.zone" = "@ 3600 IN MX 10 mx1\n"
+						}
+					}
+					data "zonefile_record_sets" "main" {
+						origin   = "main.test."
+						content  = "$INCLUDE sub/mail.zone\n"
+						includes = {
+							"sub/mail.zone"   = "$INCLUDE backup.zone\n@ 3600 IN MX 10 mx1\n"
+							"sub/backup.zone" = "@ 3600 IN MX 20 mx2\n"
+						}
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.#", "3"),
+					eq("data.zonefile_records.main", "records.1.fqdn", "main.test."),
+					eq("data.zonefile_records.main", "records.1.data", "10 mx1.main.test."),
+					eq("data.zonefile_records.main", "records.2.fqdn", "sub.main.test."),
+					eq("data.zonefile_records.main", "records.2.name", "sub"),
+					eq("data.zonefile_records.main", "records.2.data", "10 mx1.sub.main.test."),
+					eq("data.zonefile_record_sets.main", "rrsets.#", "1"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.#", "2"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.0", "20 mx2.main.test."),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.1", "10 mx1.main.test."),
+				),
+			},
+			{
+				Config: `
+					data "zonefile_records" "main" {
+						content = "$INCLUDE mail.zone\n"
+					}`,
+				ExpectError: regexp.MustCompile(`\$INCLUDE directive not allowed`),
+			},
+			{
+				Config: `
+					data "zonefile_records" "main" {
+						content  = "$INCLUDE ../mail.zone\n"
+						includes = {}
+					}`,
+				ExpectError: regexp.MustCompile(`failed to open`),
+			},
+		},
+	})
+}
+
+func TestZonefilePath(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"zones/main.zone":      "@ 3600 IN A 10.100.0.10\n$INCLUDE mail/mx.zone\n",
+		"zones/mail/mx.zone":   "@ 3600 IN MX 10 mx1\n$INCLUDE /zones/mail/txt.zone\n",
+		"zones/mail/txt.zone":  "@ 3600 IN TXT \"v=spf1 mx -all\"\n",
+		"zones/escape.zone":    "$INCLUDE ../../outside.zone\n",
+		"zones/broken.zone":    "@ 3600 IN A 10.100.0.10\n@ 3600 IN A not-an-ip\n",
+		"../outside.zone":      "@ 3600 IN A 10.100.0.10\n",
+		"elsewhere/other.zone": "$INCLUDE ../zones/mail/mx.zone\n",
+	}
+	for name, content := range files {
+		name = filepath.Join(root, "root", name)
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root = filepath.Join(root, "root")
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "zonefile" {
+						include_root = %q
+					}
+					data "zonefile_records" "main" {
+						origin = "main.test."
+						path   = %q
+					}
+					data "zonefile_record_sets" "main" {
+						origin = "main.test."
+						path   = %q
+					}`,
+					root,
+					filepath.Join(root, "zones/main.zone"),
+					filepath.Join(root, "zones/main.zone")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.#", "3"),
+					eq("data.zonefile_records.main", "records.1.data", "10 mx1.main.test."),
+					eq("data.zonefile_records.main", "records.2.txt", "v=spf1 mx -all"),
+					eq("data.zonefile_record_sets.main", "rrsets.#", "3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "zonefile" {
+						include_root = %q
+					}
+					data "zonefile_records" "main" {
+						path = %q
+					}`,
+					filepath.Join(root, "zones"),
+					filepath.Join(root, "zones/escape.zone")),
+				ExpectError: regexp.MustCompile(`failed to open`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "zonefile" {
+						include_root = %q
+					}
+					data "zonefile_records" "main" {
+						path = %q
+					}`,
+					filepath.Join(root, "zones"),
+					filepath.Join(root, "elsewhere/other.zone")),
+				ExpectError: regexp.MustCompile(`\$INCLUDE directive not allowed`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						path = %q
+					}`,
+					filepath.Join(root, "zones/main.zone")),
+				ExpectError: regexp.MustCompile(`\$INCLUDE directive not allowed`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						path = %q
+					}`,
+					filepath.Join(root, "zones/broken.zone")),
+				ExpectError: regexp.MustCompile(`broken\.zone:\s+line\s+2,\s+column\s+13:\s+bad\s+A\s+A:\s+"not-an-ip"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						content = "@ 3600 IN A 10.100.0.10\n"
+						path    = %q
+					}`,
+					filepath.Join(root, "zones/main.zone")),
+				ExpectError: regexp.MustCompile(`Exactly one of "content" or "path" must be set`),
+			},
+		},
+	})
+}
+
+func TestZonefileContentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_content" "main" {
+						origin  = %q
+						records = data.zonefile_records.main.records
+					}
+					data "zonefile_content" "explicit" {
+						ttl     = 300
+						records = [
+							{ fqdn = "www.main.test.", type = "CNAME", data = "main.test." },
+							{ fqdn = "main.test.", type = "A", ttl = 60, data = "10.100.0.10" },
+						]
+					}`,
+					testOrigin, testZonefile,
+					testOrigin),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_content.main", "content", testFormatted),
+					eq("data.zonefile_content.explicit", "content",
+						"$TTL 300\n\nwww.main.test.    IN CNAME main.test.\nmain.test.     60 IN A     10.100.0.10\n"),
+				),
+			},
+			{
+				Config: `
+					data "zonefile_content" "main" {
+						ttl     = -1
+						records = [{ fqdn = "main.test.", type = "A", data = "10.100.0.10" }]
+					}`,
+				ExpectError: regexp.MustCompile(`default TTL must be between 0 and 4294967295`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_content" "main" {
+						origin  = %q
+						records = []
+					}`,
+					testOrigin),
+				Check: eq("data.zonefile_content.main", "content", "$ORIGIN main.test.\n"),
+			},
+			{
+				Config: `
+					data "zonefile_content" "main" {
+						ttl     = 300
+						records = [{ fqdn = "main.test.", type = "A", ttl = 4294967296, data = "10.100.0.10" }]
+					}`,
+				ExpectError: regexp.MustCompile(`record 0: TTL must be between 0 and 4294967295`),
+			},
+		},
+	})
+}
+
+func TestZonefileFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					locals {
+						records = provider::zonefile::records(%q, %q)
+					}
+					output "records_count" { value = tostring(length(local.records)) }
+					output "records_0_fqdn" { value = local.records[0].fqdn }
+					output "records_0_data" { value = local.records[0].data }
+					output "records_3_mx_exchange" { value = local.records[3].mx.exchange }
+					output "records_4_name" { value = local.records[4].name }
+					output "records_4_srv_port" { value = tostring(local.records[4].srv.port) }
+					output "records_7_txt" { value = local.records[7].txt }
+
+					locals {
+						rrsets = provider::zonefile::rrsets(%q, %q)
+					}
+					output "rrsets_count" { value = tostring(length(local.rrsets)) }
+					output "rrsets_0_data_1" { value = local.rrsets[0].data[1] }
+					output "rrsets_1_mx_1_exchange" { value = local.rrsets[1].mx[1].exchange }
+					output "rrsets_2_name" { value = local.rrsets[2].name }
+					output "rrsets_2_srv_0_target" { value = local.rrsets[2].srv[0].target }
+					output "rrsets_3_txt_1" { value = local.rrsets[3].txt[1] }
+
+					output "formatted" { value = provider::zonefile::format(local.records, %q) }
+
+					locals {
+						mx  = provider::zonefile::parse_rr("@ MX 10 mx1.mail.test.", %q, 3600)
+						srv = provider::zonefile::parse_rr("srv.main.test. 1800 IN SRV 1 1 443 app1.app.test.", null, null)
+					}
+					output "mx_name" { value = coalesce(local.mx.name, "apex") }
+					output "mx_ttl" { value = tostring(local.mx.ttl) }
+					output "mx_exchange" { value = local.mx.mx.exchange }
+					output "mx_origin" { value = local.mx.origin }
+					output "srv_fqdn" { value = local.srv.fqdn }
+					output "srv_target" { value = local.srv.srv.target }`,
+					testZonefile, testOrigin,
+					testZonefile, testOrigin,
+					testOrigin,
+					testOrigin),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					out("records_count", "8"),
+					out("records_0_fqdn", "main.test."),
+					out("records_0_data", "10.100.0.10"),
+					out("records_3_mx_exchange", "mx2.mail.test."),
+					out("records_4_name", "srv"),
+					out("records_4_srv_port", "443"),
+					out("records_7_txt", "and second"),
+					out("rrsets_count", "4"),
+					out("rrsets_0_data_1", "10.200.0.20"),
+					out("rrsets_1_mx_1_exchange", "mx2.mail.test."),
+					out("rrsets_2_name", "srv"),
+					out("rrsets_2_srv_0_target", "app1.app.test."),
+					out("rrsets_3_txt_1", "and second"),
+					out("formatted", testFormatted),
+					out("mx_name", "apex"),
+					out("mx_ttl", "3600"),
+					out("mx_exchange", "mx1.mail.test."),
+					out("mx_origin", "main.test."),
+					out("srv_fqdn", "srv.main.test."),
+					out("srv_target", "app1.app.test."),
+				),
+			},
+			{
+				Config: `
+					output "records" {
+						value = provider::zonefile::records("@ IN A 10.0.0.1", null)
+					}`,
+				ExpectError: regexp.MustCompile(`Invalid zone file`),
+			},
+			{
+				Config: `
+					output "rrsets" {
+						value = provider::zonefile::rrsets("@ 60 IN A 10.0.0.1\n@ 120 IN A 10.0.0.2", "main.test.")
+					}`,
+				ExpectError: regexp.MustCompile(`inconsistent TTLs`),
+			},
+			{
+				Config: `
+					output "metadata_count" {
+						value = tostring(length(provider::zonefile::records("@ 60 IN A 10.0.0.1 ; @tf proxied", "main.test.")[0].metadata))
+					}`,
+				Check: out("metadata_count", "0"),
+			},
+			{
+				Config: `
+					output "rr" {
+						value = provider::zonefile::parse_rr("www.main.test. A 10.0.0.1", null, null)
+					}`,
+				ExpectError: regexp.MustCompile(`missing TTL`),
+			},
+			{
+				Config: `
+					output "rr" {
+						value = provider::zonefile::parse_rr("www.main.test. IN A 10.0.0.1", null, null)
+					}`,
+				ExpectError: regexp.MustCompile(`missing TTL`),
+			},
+			{
+				Config: `
+					output "ttl" {
+						value = provider::zonefile::parse_rr("www.main.test. 0 IN A 10.0.0.1", null, null).ttl
+					}`,
+				Check: out("ttl", "0"),
+			},
+			{
+				Config: `
+					output "formatted" {
+						value = provider::zonefile::format([{ name = null, fqdn = "main.test.", class = null, type = "A", ttl = -1, data = "10.0.0.1" }], null)
+					}`,
+				ExpectError: regexp.MustCompile(`record 0: TTL must be between 0 and 4294967295`),
+			},
+			{
+				Config: `
+					output "formatted" {
+						value = provider::zonefile::format([], "main.test.")
+					}`,
+				Check: out("formatted", "$ORIGIN main.test.\n"),
+			},
+		},
+	})
+}
+
+func TestZonefileComments(t *testing.T) {
+	const commentedZonefile = `
+@ 3600 IN A 10.100.0.10 ; owner: web, JIRA-123
+@ 3600 IN A 10.100.0.20
+@ 3600 IN MX ( 10 ; primary
+	mx1.mail.test. ) ; see JIRA-456
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, commentedZonefile,
+					testOrigin, commentedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.comment", "owner: web, JIRA-123"),
+					null("data.zonefile_records.main", "records.1.comment"),
+					eq("data.zonefile_records.main", "records.2.comment", "primary ; see JIRA-456"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.comments.#", "2"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.comments.0", "owner: web, JIRA-123"),
+					null("data.zonefile_record_sets.main", "rrsets.0.comments.1"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.comments.#", "1"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.comments.0", "primary ; see JIRA-456"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileMetadata(t *testing.T) {
+	const annotatedZonefile = `
+@ 3600 IN A 10.100.0.10 ; web tier @tf proxied=true owner=payments
+@ 3600 IN A 10.100.0.20 ; @tf proxied=true note="two words"
+www 3600 IN CNAME @
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, annotatedZonefile,
+					testOrigin, annotatedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.metadata.%", "2"),
+					eq("data.zonefile_records.main", "records.0.metadata.proxied", "true"),
+					eq("data.zonefile_records.main", "records.0.metadata.owner", "payments"),
+					eq("data.zonefile_records.main", "records.1.metadata.note", "two words"),
+					eq("data.zonefile_records.main", "records.2.metadata.%", "0"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.metadata.%", "3"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.metadata.proxied", "true"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.metadata.owner", "payments"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.metadata.note", "two words"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.metadata.%", "0"),
+				),
+			},
+			{
+				Config: `
+					data "zonefile_records" "main" {
+						origin  = "main.test."
+						content = "@ 3600 IN A 10.100.0.10 ; contact @tf team on slack\n@ 3600 IN A 10.100.0.20 ; @tf proxied\n"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.#", "2"),
+					eq("data.zonefile_records.main", "records.0.comment", "contact @tf team on slack"),
+					eq("data.zonefile_records.main", "records.0.metadata.%", "0"),
+					eq("data.zonefile_records.main", "records.1.metadata.%", "0"),
+				),
+			},
+			{
+				Config: `
+					data "zonefile_record_sets" "main" {
+						origin  = "main.test."
+						content = "@ 3600 IN A 10.100.0.10 ; @tf proxied=true\n@ 3600 IN A 10.100.0.20 ; @tf proxied=false\n"
+					}`,
+				ExpectError: regexp.MustCompile(`inconsistent "proxied" metadata`),
+			},
+		},
+	})
+}
+
+func TestZonefileSources(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "zonefile_records" "main" {
+						origin   = "main.test."
+						content  = "@ 3600 IN A 10.100.0.10\n\n$INCLUDE mail.zone\n$GENERATE 1-2 host$ 3600 IN A 10.100.1.$\n"
+						includes = {
+							"mail.zone" = "; Mail servers\n@ 3600 IN MX (\n\t10 mx1 )\n"
+						}
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = "main.test."
+						content = "@ 3600 IN A 10.100.0.10\n@ 3600 IN A 10.100.0.20\n"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					null("data.zonefile_records.main", "records.0.source.file"),
+					eq("data.zonefile_records.main", "records.0.source.line", "1"),
+					eq("data.zonefile_records.main", "records.0.source.generated", "false"),
+					null("data.zonefile_records.main", "records.0.source.generate_line"),
+					eq("data.zonefile_records.main", "records.1.source.file", "mail.zone"),
+					eq("data.zonefile_records.main", "records.1.source.line", "2"),
+					eq("data.zonefile_records.main", "records.2.fqdn", "host1.main.test."),
+					null("data.zonefile_records.main", "records.2.source.file"),
+					eq("data.zonefile_records.main", "records.2.source.line", "4"),
+					eq("data.zonefile_records.main", "records.2.source.generated", "true"),
+					eq("data.zonefile_records.main", "records.2.source.generate_line", "4"),
+					eq("data.zonefile_records.main", "records.3.source.generate_line", "4"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.sources.#", "2"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.sources.0.line", "1"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.sources.1.line", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileKeys(t *testing.T) {
+	const keyedZonefile = `
+@ 3600 IN A 10.100.0.10
+@ 3600 IN A 10.200.0.20
+@ 3600 IN A 10.100.0.10
+www 3600 IN CNAME @
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin     = %q
+						content    = %q
+						duplicates = "keep"
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_record_sets" "unqualified" {
+						content = %q
+					}`,
+					testOrigin, keyedZonefile,
+					testOrigin, keyedZonefile,
+					"$ORIGIN main.test.\n"+keyedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.key", "@/A/IN/efe94883"),
+					eq("data.zonefile_records.main", "records.1.key", "@/A/IN/a1f0d328"),
+					eq("data.zonefile_records.main", "records.2.key", "@/A/IN/efe94883-2"),
+					eq("data.zonefile_records.main", "records_by_key.%", "4"),
+					eq("data.zonefile_records.main", "records_by_key.@/A/IN/a1f0d328.data", "10.200.0.20"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.key", "@/A/IN"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.key", "www/CNAME/IN"),
+					eq("data.zonefile_record_sets.main", "rrsets_by_key.%", "2"),
+					eq("data.zonefile_record_sets.main", "rrsets_by_key.www/CNAME/IN.data.0", "main.test."),
+					eq("data.zonefile_record_sets.unqualified", "rrsets.1.key", "www.main.test./CNAME/IN"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileNameCase(t *testing.T) {
+	const mixedCaseZonefile = `
+WWW 3600 IN A 10.100.0.10
+www 3600 IN A 10.100.0.20
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_records" "normalized" {
+						origin          = "Main.Test."
+						content         = %q
+						normalize_names = true
+					}
+					data "zonefile_record_sets" "directive" {
+						origin          = %q
+						content         = "$ORIGIN Dev.Main.Test.\nAPI 60 IN A 10.100.0.10\n"
+						normalize_names = true
+					}`,
+					testOrigin, mixedCaseZonefile,
+					mixedCaseZonefile,
+					testOrigin),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_record_sets.main", "rrsets.#", "1"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.fqdn", "WWW.main.test."),
+					eq("data.zonefile_record_sets.main", "rrsets.0.name", "WWW"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.canonical_fqdn", "www.main.test."),
+					eq("data.zonefile_record_sets.main", "rrsets.0.key", "www/A/IN"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.#", "2"),
+					eq("data.zonefile_records.normalized", "records.0.fqdn", "www.main.test."),
+					eq("data.zonefile_records.normalized", "records.0.name", "www"),
+					eq("data.zonefile_records.normalized", "records.0.canonical_fqdn", "www.main.test."),
+					eq("data.zonefile_records.normalized", "effective_origin", "main.test."),
+					eq("data.zonefile_records.normalized", "records.0.origin", "main.test."),
+					eq("data.zonefile_record_sets.directive", "rrsets.0.name", "api.dev"),
+					eq("data.zonefile_record_sets.directive", "rrsets.0.origin", "dev.main.test."),
+					eq("data.zonefile_record_sets.directive", "rrsets.0.name_relative_to_directive", "api"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileDuplicates(t *testing.T) {
+	const duplicatedZonefile = `
+@ 3600 IN A 10.100.0.10
+@ 3600 IN A 10.200.0.20
+@ 300 IN A 10.100.0.10
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "keep" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_record_sets" "warn" {
+						origin     = %q
+						content    = %q
+						duplicates = "warn"
+					}`,
+					testOrigin, duplicatedZonefile,
+					testOrigin, duplicatedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.keep", "records.#", "3"),
+					eq("data.zonefile_record_sets.warn", "rrsets.#", "1"),
+					eq("data.zonefile_record_sets.warn", "rrsets.0.ttl", "3600"),
+					eq("data.zonefile_record_sets.warn", "rrsets.0.data.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin     = %q
+						content    = %q
+						duplicates = "error"
+					}`,
+					testOrigin, duplicatedZonefile),
+				ExpectError: regexp.MustCompile(`(?s)line 4: duplicate IN A main.test.\s+record\s+\(first\s+at\s+line\s+2\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin     = %q
+						content    = %q
+						duplicates = "drop"
+					}`,
+					testOrigin, duplicatedZonefile),
+				ExpectError: regexp.MustCompile(`duplicates must be one of "error", "warn",\s+or\s+"keep"`),
+			},
+		},
+	})
+}
+
+func TestZonefileTTLConflict(t *testing.T) {
+	const conflictingZonefile = `
+@ 300 IN A 10.100.0.10
+@ 3600 IN A 10.200.0.20
+@ 60 IN A 10.250.0.30
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "min" {
+						origin       = %[1]q
+						content      = %[2]q
+						ttl_conflict = "min"
+					}
+					data "zonefile_record_sets" "max" {
+						origin       = %[1]q
+						content      = %[2]q
+						ttl_conflict = "max"
+					}
+					data "zonefile_record_sets" "first" {
+						origin       = %[1]q
+						content      = %[2]q
+						ttl_conflict = "first"
+					}`,
+					testOrigin, conflictingZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_record_sets.min", "rrsets.0.ttl", "60"),
+					eq("data.zonefile_record_sets.max", "rrsets.0.ttl", "3600"),
+					eq("data.zonefile_record_sets.first", "rrsets.0.ttl", "300"),
+					eq("data.zonefile_record_sets.first", "rrsets.0.data.#", "3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin       = %q
+						content      = %q
+						ttl_conflict = "error"
+					}`,
+					testOrigin, conflictingZonefile),
+				ExpectError: regexp.MustCompile(`inconsistent TTLs`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin       = %q
+						content      = %q
+						ttl_conflict = "average"
+					}`,
+					testOrigin, conflictingZonefile),
+				ExpectError: regexp.MustCompile(`Invalid ttl_conflict`),
+			},
+		},
+	})
+}
+
+func TestZonefileOriginMode(t *testing.T) {
+	const directiveZonefile = `
+$ORIGIN main.test.
+www 3600 IN A 10.100.0.10
+`
+	const soaZonefile = `
+main.test. 3600 IN SOA ns.main.test. hostmaster.main.test. 1 7200 3600 1209600 3600
+www.main.test. 3600 IN A 10.100.0.10
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "directive" {
+						content     = %q
+						origin_mode = "from_directive"
+					}
+					data "zonefile_record_sets" "soa" {
+						content     = %q
+						origin_mode = "from_soa"
+					}
+					data "zonefile_records" "fallback" {
+						origin      = "other.test"
+						content     = %q
+						origin_mode = "from_soa"
+					}
+					data "zonefile_records" "explicit" {
+						content = %q
+					}`,
+					directiveZonefile, soaZonefile, directiveZonefile, directiveZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.directive", "effective_origin", testOrigin),
+					eq("data.zonefile_records.directive", "records.0.name", "www"),
+					eq("data.zonefile_record_sets.soa", "effective_origin", testOrigin),
+					null("data.zonefile_record_sets.soa", "rrsets.0.name"),
+					eq("data.zonefile_record_sets.soa", "rrsets.1.name", "www"),
+					eq("data.zonefile_records.fallback", "effective_origin", "other.test."),
+					null("data.zonefile_records.explicit", "effective_origin"),
+					null("data.zonefile_records.explicit", "records.0.name"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						content     = %q
+						origin_mode = "from_soa"
+					}`,
+					directiveZonefile),
+				ExpectError: regexp.MustCompile(`no\s+SOA\s+record`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						content     = %q
+						origin_mode = "from_file"
+					}`,
+					directiveZonefile),
+				ExpectError: regexp.MustCompile(`Invalid origin_mode`),
+			},
+		},
+	})
+}
+
+func TestZonefileRecordOrigins(t *testing.T) {
+	const multiOriginZonefile = `
+www 3600 IN A 10.100.0.10
+$ORIGIN eu.main.test.
+@ 3600 IN A 10.200.0.10
+www 3600 IN A 10.200.0.20
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, multiOriginZonefile,
+					testOrigin, multiOriginZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.origin", testOrigin),
+					eq("data.zonefile_records.main", "records.0.name", "www"),
+					eq("data.zonefile_records.main", "records.0.name_relative_to_directive", "www"),
+					eq("data.zonefile_records.main", "records.1.origin", "eu.main.test."),
+					eq("data.zonefile_records.main", "records.1.name", "eu"),
+					null("data.zonefile_records.main", "records.1.name_relative_to_directive"),
+					eq("data.zonefile_records.main", "records.2.origin", "eu.main.test."),
+					eq("data.zonefile_records.main", "records.2.name", "www.eu"),
+					eq("data.zonefile_records.main", "records.2.name_relative_to_directive", "www"),
+					eq("data.zonefile_record_sets.main", "rrsets.2.origin", "eu.main.test."),
+					eq("data.zonefile_record_sets.main", "rrsets.2.name_relative_to_directive", "www"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileOutOfZone(t *testing.T) {
+	const outOfZoneZonefile = `
+www 3600 IN A 10.100.0.10
+www.other.test. 3600 IN A 10.200.0.20
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "warn" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_records" "drop" {
+						origin      = %[1]q
+						content     = %[2]q
+						out_of_zone = "drop"
+					}
+					data "zonefile_record_sets" "allow" {
+						origin      = %[1]q
+						content     = %[2]q
+						out_of_zone = "allow"
+					}
+					data "zonefile_records" "no_origin" {
+						content = %[3]q
+					}`,
+					testOrigin, outOfZoneZonefile, "www.other.test. 3600 IN A 10.200.0.20"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.warn", "records.#", "2"),
+					eq("data.zonefile_records.warn", "records.0.in_zone", "true"),
+					eq("data.zonefile_records.warn", "records.1.in_zone", "false"),
+					eq("data.zonefile_records.drop", "records.#", "1"),
+					eq("data.zonefile_records.drop", "records.0.fqdn", "www.main.test."),
+					eq("data.zonefile_record_sets.allow", "rrsets.#", "2"),
+					eq("data.zonefile_record_sets.allow", "rrsets.1.in_zone", "false"),
+					null("data.zonefile_records.no_origin", "records.0.in_zone"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin      = %q
+						content     = %q
+						out_of_zone = "allow"
+					}`,
+					testOrigin, "xmain.test. 3600 IN A 10.250.0.30"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.name", "xmain.test"),
+					eq("data.zonefile_records.main", "records.0.name_relative_to_directive", "xmain.test"),
+					eq("data.zonefile_records.main", "records.0.in_zone", "false"),
+					resource.TestMatchResourceAttr("data.zonefile_records.main", "records.0.key", regexp.MustCompile(`^xmain\.test\./A/IN/`)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin      = %q
+						content     = %q
+						out_of_zone = "error"
+					}`,
+					testOrigin, outOfZoneZonefile),
+				ExpectError: regexp.MustCompile(`line 3: IN A www.other.test. record is\s+outside\s+of\s+the\s+zone\s+main.test.`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin      = %q
+						content     = %q
+						out_of_zone = "ignore"
+					}`,
+					testOrigin, outOfZoneZonefile),
+				ExpectError: regexp.MustCompile(`Invalid out_of_zone`),
+			},
+		},
+	})
+}
+
+func TestZonefileTrailingDots(t *testing.T) {
+	const missingDotZonefile = `
+www 3600 IN CNAME web.main.test
+mail 3600 IN MX 10 mx.other.test
+web 3600 IN A 10.100.0.10
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, missingDotZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.#", "3"),
+					eq("data.zonefile_records.main", "records.0.data", "web.main.test.main.test."),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin                = %q
+						content               = %q
+						missing_trailing_dots = "error"
+					}`,
+					testOrigin, missingDotZonefile),
+				ExpectError: regexp.MustCompile(`(?s)line 2: IN CNAME www.main.test. record has target\s+web.main.test.main.test.*line 3: IN MX mail.main.test. record has target\s+mx.other.test.main.test.`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin                = %q
+						content               = %q
+						missing_trailing_dots = "error"
+					}`,
+					"2.0.192.in-addr.arpa.", "1 3600 IN PTR host.example.com\n2 3600 IN PTR host"),
+				ExpectError: regexp.MustCompile(`(?s)line 1: IN PTR 1.2.0.192.in-addr.arpa. record has target\s+host.example.com.2.0.192.in-addr.arpa.\s+.*did\s+you\s+mean\s+host.example.com.\?`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin                = %q
+						content               = %q
+						missing_trailing_dots = "error"
+					}`,
+					testOrigin, "www 3600 IN CNAME web.dev\nweb.dev 3600 IN A 10.100.0.10"),
+				Check: eq("data.zonefile_records.main", "records.0.data", "web.dev.main.test."),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin                = %q
+						content               = %q
+						missing_trailing_dots = "fix"
+					}`,
+					testOrigin, missingDotZonefile),
+				ExpectError: regexp.MustCompile(`Invalid missing_trailing_dots`),
+			},
+		},
+	})
+}
+
+func TestZonefileFilters(t *testing.T) {
+	const filteredZonefile = `
+@ 3600 IN MX 10 mx
+@ 3600 IN TXT "v=spf1 -all"
+www 3600 IN A 10.100.0.10
+www 3600 IN AAAA fd00::10
+_acme-challenge 3600 IN TXT "token"
+api.dev 3600 IN A 10.100.0.20
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "mail" {
+						origin        = %[1]q
+						content       = %[2]q
+						include_types = ["MX", "txt"]
+						exclude_names = ["/^_/"]
+					}
+					data "zonefile_record_sets" "web" {
+						origin        = %[1]q
+						content       = %[2]q
+						exclude_types = ["MX", "TXT"]
+						include_names = ["www", "*.dev.main.test."]
+					}
+					data "zonefile_records" "chaos" {
+						origin          = %[1]q
+						content         = %[2]q
+						include_classes = ["CH"]
+					}`,
+					testOrigin, filteredZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.mail", "records.#", "2"),
+					eq("data.zonefile_records.mail", "records.0.type", "MX"),
+					eq("data.zonefile_records.mail", "records.1.type", "TXT"),
+					eq("data.zonefile_record_sets.web", "rrsets.#", "3"),
+					eq("data.zonefile_record_sets.web", "rrsets.0.type", "A"),
+					eq("data.zonefile_record_sets.web", "rrsets.1.type", "AAAA"),
+					eq("data.zonefile_record_sets.web", "rrsets.2.name", "api.dev"),
+					eq("data.zonefile_records.chaos", "records.#", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin        = %q
+						content       = %q
+						include_types = ["BOGUS"]
+					}`,
+					testOrigin, filteredZonefile),
+				ExpectError: regexp.MustCompile(`"BOGUS" is not a known record type`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin        = %q
+						content       = %q
+						include_names = ["/(/"]
+					}`,
+					testOrigin, filteredZonefile),
+				ExpectError: regexp.MustCompile(`not a valid regular expression`),
+			},
+		},
+	})
+}
+
+func TestZonefileOrder(t *testing.T) {
+	const unorderedZonefile = `
+z 3600 IN A 10.100.0.30
+a.z 3600 IN A 10.100.0.20
+@ 3600 IN TXT "second"
+@ 3600 IN TXT "first"
+b 3600 IN A 10.100.0.10
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "file" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_records" "canonical" {
+						origin  = %[1]q
+						content = %[2]q
+						order   = "canonical"
+					}
+					data "zonefile_record_sets" "name_type" {
+						origin  = %[1]q
+						content = %[2]q
+						order   = "name_type"
+					}`,
+					testOrigin, unorderedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.file", "records.0.name", "z"),
+					eq("data.zonefile_records.file", "records.4.name", "b"),
+					null("data.zonefile_records.canonical", "records.0.name"),
+					eq("data.zonefile_records.canonical", "records.0.txt", "first"),
+					eq("data.zonefile_records.canonical", "records.1.txt", "second"),
+					eq("data.zonefile_records.canonical", "records.2.name", "b"),
+					eq("data.zonefile_records.canonical", "records.3.name", "z"),
+					eq("data.zonefile_records.canonical", "records.4.name", "a.z"),
+					eq("data.zonefile_record_sets.name_type", "rrsets.0.name", "a.z"),
+					eq("data.zonefile_record_sets.name_type", "rrsets.1.name", "b"),
+					null("data.zonefile_record_sets.name_type", "rrsets.2.name"),
+					eq("data.zonefile_record_sets.name_type", "rrsets.2.txt.0", "second"),
+					eq("data.zonefile_record_sets.name_type", "rrsets.3.name", "z"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_record_sets" "main" {
+						origin       = %q
+						content      = %q
+						order        = "canonical"
+						ttl_conflict = "first"
+					}`,
+					testOrigin, `
+a 300 IN A 10.0.0.9
+a 60 IN A 10.0.0.1
+@ 3600 IN MX 10 B.example.
+@ 3600 IN MX 10 a.example.
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					null("data.zonefile_record_sets.main", "rrsets.0.name"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.0", "10 a.example."),
+					eq("data.zonefile_record_sets.main", "rrsets.0.data.1", "10 B.example."),
+					eq("data.zonefile_record_sets.main", "rrsets.1.name", "a"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.ttl", "300"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.data.0", "10.0.0.1"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.data.1", "10.0.0.9"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %q
+						content = %q
+						order   = "random"
+					}`,
+					testOrigin, unorderedZonefile),
+				ExpectError: regexp.MustCompile(`Invalid order`),
+			},
+		},
+	})
+}
+
+func TestZonefileManagedByHost(t *testing.T) {
+	const hostedZonefile = `
+@ 3600 IN SOA ns1 hostmaster 1 7200 3600 1209600 3600
+@ 3600 IN NS ns1
+@ 3600 IN NS ns2
+@ 3600 IN A 10.100.0.10
+dev 3600 IN NS ns1.dev
+ns1 3600 IN A 10.100.0.53
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "managed" {
+						origin          = %[1]q
+						content         = %[2]q
+						managed_by_host = true
+					}
+					data "zonefile_record_sets" "managed" {
+						content         = %[2]q
+						origin_mode     = "from_soa"
+						origin          = %[1]q
+						managed_by_host = true
+					}
+					data "zonefile_records" "unmanaged" {
+						origin  = %[1]q
+						content = %[2]q
+					}`,
+					testOrigin, hostedZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.managed", "records.#", "3"),
+					eq("data.zonefile_records.managed", "records.0.type", "A"),
+					eq("data.zonefile_records.managed", "records.1.name", "dev"),
+					eq("data.zonefile_records.managed", "soa.type", "SOA"),
+					eq("data.zonefile_records.managed", "apex_ns.#", "2"),
+					eq("data.zonefile_records.managed", "apex_ns.1.data", "ns2.main.test."),
+					eq("data.zonefile_record_sets.managed", "rrsets.#", "3"),
+					eq("data.zonefile_record_sets.managed", "soa.data.#", "1"),
+					eq("data.zonefile_record_sets.managed", "apex_ns.data.#", "2"),
+					eq("data.zonefile_records.unmanaged", "records.#", "6"),
+					eq("data.zonefile_records.unmanaged", "soa.type", "SOA"),
+					eq("data.zonefile_records.unmanaged", "apex_ns.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileSOA(t *testing.T) {
+	const soaZonefile = `
+@ 3600 IN SOA ns1 john\.doe.example.com. 2024010101 7200 3600 1209600 300
+@ 3600 IN NS ns1
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_soa" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_records" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}`,
+					testOrigin, soaZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_soa.main", "fqdn", testOrigin),
+					eq("data.zonefile_soa.main", "mname", "ns1.main.test."),
+					eq("data.zonefile_soa.main", "rname", `john\.doe.example.com.`),
+					eq("data.zonefile_soa.main", "rname_email", "john.doe@example.com"),
+					eq("data.zonefile_soa.main", "serial", "2024010101"),
+					eq("data.zonefile_soa.main", "refresh", "7200"),
+					eq("data.zonefile_soa.main", "retry", "3600"),
+					eq("data.zonefile_soa.main", "expire", "1209600"),
+					eq("data.zonefile_soa.main", "minimum", "300"),
+					eq("data.zonefile_soa.main", "source.line", "2"),
+					eq("data.zonefile_records.main", "records.0.soa.serial", "2024010101"),
+					null("data.zonefile_records.main", "records.1.soa"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.soa.0.rname_email", "john.doe@example.com"),
+					null("data.zonefile_record_sets.main", "rrsets.1.soa"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_soa" "main" {
+						origin  = %q
+						content = %q
+					}`,
+					testOrigin, "@ 3600 IN A 10.100.0.10"),
+				ExpectError: regexp.MustCompile(`The zone file has no SOA record`),
+			},
+		},
+	})
+}
+
+func TestZonefileCAA(t *testing.T) {
+	const caaZonefile = `
+@ 3600 IN CAA 0 issue "letsencrypt.org; accounturi=https://acme.example/acct/1; validationmethods=dns-01"
+@ 3600 IN CAA 128 issuewild ";"
+@ 3600 IN CAA 0 iodef "mailto:security@main.test"
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}`,
+					testOrigin, caaZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.caa.flag", "0"),
+					eq("data.zonefile_records.main", "records.0.caa.tag", "issue"),
+					eq("data.zonefile_records.main", "records.0.caa.critical", "false"),
+					eq("data.zonefile_records.main", "records.0.caa.issuer", "letsencrypt.org"),
+					eq("data.zonefile_records.main", "records.0.caa.parameters.accounturi", "https://acme.example/acct/1"),
+					eq("data.zonefile_records.main", "records.0.caa.parameters.validationmethods", "dns-01"),
+					eq("data.zonefile_records.main", "records.1.caa.critical", "true"),
+					null("data.zonefile_records.main", "records.1.caa.issuer"),
+					eq("data.zonefile_records.main", "records.1.caa.parameters.%", "0"),
+					eq("data.zonefile_records.main", "records.2.caa.value", "mailto:security@main.test"),
+					null("data.zonefile_records.main", "records.2.caa.parameters"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.caa.#", "3"),
+					eq("data.zonefile_record_sets.main", "rrsets.0.caa.0.issuer", "letsencrypt.org"),
+					null("data.zonefile_record_sets.main", "rrsets.0.mx"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonefileFingerprints(t *testing.T) {
+	const fingerprintZonefile = `
+host 3600 IN SSHFP 4 2 123456789abcdef67890123456789abcdef67890123456789abcdef123456789
+_443._tcp 3600 IN TLSA 3 1 1 d2abde240d7cd3ee6b4b28c54df034b97983a1d16e8a410e4561cb106618e971
+x._smimecert 3600 IN SMIMEA 9 0 0 d2abde24
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zonefile_records" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}
+					data "zonefile_record_sets" "main" {
+						origin  = %[1]q
+						content = %[2]q
+					}`,
+					testOrigin, fingerprintZonefile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					eq("data.zonefile_records.main", "records.0.sshfp.algorithm", "4"),
+					eq("data.zonefile_records.main", "records.0.sshfp.algorithm_name", "Ed25519"),
+					eq("data.zonefile_records.main", "records.0.sshfp.fp_type_name", "SHA-256"),
+					eq("data.zonefile_records.main", "records.0.sshfp.fingerprint", "123456789ABCDEF67890123456789ABCDEF67890123456789ABCDEF123456789"),
+					null("data.zonefile_records.main", "records.0.tlsa"),
+					eq("data.zonefile_records.main", "records.1.tlsa.usage_name", "DANE-EE"),
+					eq("data.zonefile_records.main", "records.1.tlsa.selector_name", "SPKI"),
+					eq("data.zonefile_records.main", "records.1.tlsa.matching_type_name", "SHA2-256"),
+					eq("data.zonefile_records.main", "records.2.smimea.usage", "9"),
+					null("data.zonefile_records.main", "records.2.smimea.usage_name"),
+					eq("data.zonefile_records.main", "records.2.smimea.certificate", "D2ABDE24"),
+					eq("data.zonefile_record_sets.main", "rrsets.1.tlsa.0.usage", "3"),
+					null("data.zonefile_record_sets.main", "rrsets.1.sshfp"),
+				),
+			},
+		},
+	})
+}
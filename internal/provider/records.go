@@ -5,15 +5,17 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miekg/dns"
 	"github.com/samber/lo"
 )
 
-var _ datasource.DataSource = &RecordsDataSource{}
+var _ datasource.DataSourceWithConfigure = &RecordsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RecordsDataSource{}
 
-type RecordsDataSource struct{}
+type RecordsDataSource struct {
+	includeRoot string
+}
 
 func NewRecordsDataSource() datasource.DataSource {
 	return &RecordsDataSource{}
@@ -30,6 +32,15 @@ func (d *RecordsDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 	}
 }
 
+func (d *RecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.includeRoot = configureIncludeRoot(req, resp)
+}
+
+func (d *RecordsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	validateZoneSourceConfig(ctx, req.Config, resp)
+	validateZoneOptionsConfig(ctx, req.Config, resp)
+}
+
 func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data RecordsModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -37,28 +48,90 @@ func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	origin := data.Origin.ValueString()
-	rrs, err := readZone(origin, data.Content.ValueString())
-	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid zone file", err.Error()))
+	rrs, origin, diags := readZoneConfig(zoneConfig{
+		Content:        data.Content,
+		Path:           data.Path,
+		Origin:         data.Origin,
+		Includes:       data.Includes,
+		MaxErrors:      data.MaxErrors,
+		OriginMode:     data.OriginMode,
+		NormalizeNames: data.NormalizeNames,
+		Duplicates:     data.Duplicates,
+		OutOfZone:      data.OutOfZone,
+		TrailingDots:   data.TrailingDots,
+		Order:          data.Order,
+		IncludeTypes:   data.IncludeTypes,
+		ExcludeTypes:   data.ExcludeTypes,
+		IncludeNames:   data.IncludeNames,
+		ExcludeNames:   data.ExcludeNames,
+		IncludeClasses: data.IncludeClasses,
+	}, d.includeRoot)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.EffectiveOrigin = originModelValue(origin)
 
-	data.Records = lo.Map(rrs, func(rr dns.RR, _ int) RecordsItemModel {
+	apex := lo.Ternary(origin != "", origin, zone{RRs: rrs}.soaOrigin())
+	sortRRs(rrs, data.Order.ValueString())
+
+	var soa, apexNS, others []zoneRR
+	for _, rr := range rrs {
+		switch hostManagedType(rr.RR.Header(), apex) {
+		case dns.TypeSOA:
+			soa = append(soa, rr)
+		case dns.TypeNS:
+			apexNS = append(apexNS, rr)
+		default:
+			others = append(others, rr)
+		}
+	}
+	if data.ManagedByHost.ValueBool() {
+		rrs = others
+	}
+	if len(soa) > 0 {
+		data.SOA = &recordsItemModels(soa[:1], origin)[0]
+	}
+	data.ApexNS = recordsItemModels(apexNS, origin)
+
+	data.Records = recordsItemModels(rrs, origin)
+	data.RecordsByKey = lo.KeyBy(data.Records, func(r RecordsItemModel) string { return r.Key.ValueString() })
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func recordsItemModels(rrs []zoneRR, origin string) []RecordsItemModel {
+	keys := recordKeys(rrs, origin)
+	return lo.Map(rrs, func(zrr zoneRR, i int) RecordsItemModel {
+		rr := zrr.RR
 		hdr := rr.Header()
 		return RecordsItemModel{
+			Key:   types.StringValue(keys[i]),
 			Name:  nameModelValue(hdr.Name, origin),
 			FQDN:  types.StringValue(hdr.Name),
 			Class: types.StringValue(dns.ClassToString[hdr.Class]),
 			Type:  types.StringValue(dns.TypeToString[hdr.Rrtype]),
 			TTL:   types.Int64Value(int64(hdr.Ttl)),
 
+			CanonicalFQDN:           types.StringValue(dns.CanonicalName(hdr.Name)),
+			Origin:                  originModelValue(zrr.Origin),
+			NameRelativeToDirective: nameModelValue(hdr.Name, zrr.Origin),
+			InZone:                  inZoneModelValue(hdr.Name, origin),
+
 			Data: rdataModelValue(rr),
+			CAA:  caaModelValue(rr),
 			MX:   mxModelValue(rr),
+			SOA:  soaModelValue(rr),
 			SRV:  srvModelValue(rr),
 			TXT:  txtModelValue(rr),
+
+			SSHFP:  sshfpModelValue(rr),
+			TLSA:   tlsaModelValue(rr),
+			SMIMEA: smimeaModelValue(rr),
+
+			Comment:  commentModelValue(zrr.Comment),
+			Metadata: zrr.Metadata,
+			Source:   sourceModelValue(zrr),
 		}
 	})
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
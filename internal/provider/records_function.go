@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &RecordsFunction{}
+
+type RecordsFunction struct{}
+
+func NewRecordsFunction() function.Function {
+	return &RecordsFunction{}
+}
+
+func (f *RecordsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "records"
+}
+
+func (f *RecordsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a DNS zone file into a flat list of resource records.",
+		Description: ("Parse a DNS zone file into a flat list of resource records, " +
+			"with the same structure as the records attribute of the zonefile_records data source."),
+		Parameters: functionParamsHead,
+		Return:     function.ListReturn{ElementType: attributeObjectRecordsItemModel.Type()},
+	}
+}
+
+func (f *RecordsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content, origin types.String
+	resp.Error = req.Arguments.Get(ctx, &content, &origin)
+	if resp.Error != nil {
+		return
+	}
+
+	z, err := readZone(zoneSource{
+		Origin:    origin.ValueString(),
+		Content:   content.ValueString(),
+		MaxErrors: defaultMaxErrors,
+	})
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid zone file: "+errorsDetail(err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, recordsItemModels(z.RRs, origin.ValueString()))
+}
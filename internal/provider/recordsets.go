@@ -13,9 +13,12 @@ import (
 	"github.com/samber/lo"
 )
 
-var _ datasource.DataSource = &RecordSetsDataSource{}
+var _ datasource.DataSourceWithConfigure = &RecordSetsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RecordSetsDataSource{}
 
-type RecordSetsDataSource struct{}
+type RecordSetsDataSource struct {
+	includeRoot string
+}
 
 func NewRecordSetsDataSource() datasource.DataSource {
 	return &RecordSetsDataSource{}
@@ -32,6 +35,16 @@ func (d *RecordSetsDataSource) Schema(ctx context.Context, req datasource.Schema
 	}
 }
 
+func (d *RecordSetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.includeRoot = configureIncludeRoot(req, resp)
+}
+
+func (d *RecordSetsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	validateZoneSourceConfig(ctx, req.Config, resp)
+	validateZoneOptionsConfig(ctx, req.Config, resp)
+	validateOneOf(ctx, req.Config, resp, "ttl_conflict", ttlConflictError, ttlConflictMin, ttlConflictMax, ttlConflictFirst)
+}
+
 func (d *RecordSetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data RecordSetsModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -39,65 +52,174 @@ func (d *RecordSetsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	origin := data.Origin.ValueString()
-	rrs, err := readZone(origin, data.Content.ValueString())
-	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid zone file", err.Error()))
+	rrs, origin, diags := readZoneConfig(zoneConfig{
+		Content:        data.Content,
+		Path:           data.Path,
+		Origin:         data.Origin,
+		Includes:       data.Includes,
+		MaxErrors:      data.MaxErrors,
+		OriginMode:     data.OriginMode,
+		NormalizeNames: data.NormalizeNames,
+		Duplicates:     data.Duplicates,
+		OutOfZone:      data.OutOfZone,
+		TrailingDots:   data.TrailingDots,
+		Order:          data.Order,
+		IncludeTypes:   data.IncludeTypes,
+		ExcludeTypes:   data.ExcludeTypes,
+		IncludeNames:   data.IncludeNames,
+		ExcludeNames:   data.ExcludeNames,
+		IncludeClasses: data.IncludeClasses,
+	}, d.includeRoot)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.EffectiveOrigin = originModelValue(origin)
 
-	rrSets, err := groupRRs(rrs)
+	rrSets, warnings, err := groupRRs(rrs, data.TTLConflict.ValueString())
 	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Can't group some RRs into RRSets", err.Error()))
+		resp.Diagnostics.Append(zoneErrorDiagnostics("Can't group some RRs into RRSets", err, zoneSourceAttribute(data.Path))...)
 		return
 	}
+	resp.Diagnostics.Append(zoneWarningDiagnostics("Inconsistent TTLs in RRSet", warnings, zoneSourceAttribute(data.Path))...)
+
+	apex := lo.Ternary(origin != "", origin, zone{RRs: rrs}.soaOrigin())
+	sortRRSets(rrSets, data.Order.ValueString())
+
+	var hostManaged, others []rrSet
+	for _, set := range rrSets {
+		switch hostManagedType(&set.Hdr, apex) {
+		case dns.TypeSOA, dns.TypeNS:
+			hostManaged = append(hostManaged, set)
+		default:
+			others = append(others, set)
+		}
+	}
+	if data.ManagedByHost.ValueBool() {
+		rrSets = others
+	}
+	hostManagedModels, diags := recordSetsItemModels(ctx, hostManaged, origin)
+	resp.Diagnostics.Append(diags...)
+	for i, set := range hostManaged {
+		model, rrtype := &hostManagedModels[i], hostManagedType(&set.Hdr, apex)
+		switch {
+		case rrtype == dns.TypeSOA && data.SOA == nil:
+			data.SOA = model
+		case rrtype == dns.TypeNS && data.ApexNS == nil:
+			data.ApexNS = model
+		}
+	}
+
+	data.RRSets, diags = recordSetsItemModels(ctx, rrSets, origin)
+	data.RRSetsByKey = lo.KeyBy(data.RRSets, func(r RecordSetsItemModel) string { return r.Key.ValueString() })
+	resp.Diagnostics.Append(diags...)
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+}
 
+func recordSetsItemModels(ctx context.Context, rrSets []rrSet, origin string) ([]RecordSetsItemModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	tryList := func(list basetypes.ListValue, diag diag.Diagnostics) basetypes.ListValue {
-		resp.Diagnostics.Append(diag...)
+		diags.Append(diag...)
 		return list
 	}
 
-	data.RRSets = lo.Map(rrSets, func(set rrSet, _ int) RecordSetsItemModel {
+	models := lo.Map(rrSets, func(set rrSet, _ int) RecordSetsItemModel {
 		hdr := set.Hdr
 		return RecordSetsItemModel{
+			Key:   types.StringValue(rrSetKey(&hdr, origin)),
 			Name:  nameModelValue(hdr.Name, origin),
 			FQDN:  types.StringValue(hdr.Name),
 			Class: types.StringValue(dns.ClassToString[hdr.Class]),
 			Type:  types.StringValue(dns.TypeToString[hdr.Rrtype]),
 			TTL:   types.Int64Value(int64(hdr.Ttl)),
 
+			CanonicalFQDN:           types.StringValue(dns.CanonicalName(hdr.Name)),
+			Origin:                  originModelValue(set.Origin),
+			NameRelativeToDirective: nameModelValue(hdr.Name, set.Origin),
+			InZone:                  inZoneModelValue(hdr.Name, origin),
+
 			Data: tryList(types.ListValue(types.StringType,
-				lo.Map(set.RRs, func(rr dns.RR, _ int) attr.Value {
-					return rdataModelValue(rr)
+				lo.Map(set.RRs, func(rr zoneRR, _ int) attr.Value {
+					return rdataModelValue(rr.RR)
 				}))),
 
+			CAA: lo.Ternary(
+				hdr.Rrtype != dns.TypeCAA,
+				types.ListNull(attributeObjectCAAModel.Type()),
+				tryList(types.ListValueFrom(ctx, attributeObjectCAAModel.Type(),
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsCAAModel {
+						return caaModelValue(rr.RR)
+					})))),
+
 			MX: lo.Ternary(
 				hdr.Rrtype != dns.TypeMX,
 				types.ListNull(attributeObjectMXModel.Type()),
 				tryList(types.ListValueFrom(ctx, attributeObjectMXModel.Type(),
-					lo.Map(set.RRs, func(rr dns.RR, _ int) *RecordsMXModel {
-						return mxModelValue(rr)
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsMXModel {
+						return mxModelValue(rr.RR)
+					})))),
+
+			SOA: lo.Ternary(
+				hdr.Rrtype != dns.TypeSOA,
+				types.ListNull(attributeObjectSOAModel.Type()),
+				tryList(types.ListValueFrom(ctx, attributeObjectSOAModel.Type(),
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsSOAModel {
+						return soaModelValue(rr.RR)
 					})))),
 
 			SRV: lo.Ternary(
 				hdr.Rrtype != dns.TypeSRV,
 				types.ListNull(attributeObjectSRVModel.Type()),
 				tryList(types.ListValueFrom(ctx, attributeObjectSRVModel.Type(),
-					lo.Map(set.RRs, func(rr dns.RR, _ int) *RecordsSRVModel {
-						return srvModelValue(rr)
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsSRVModel {
+						return srvModelValue(rr.RR)
 					})))),
 
 			TXT: lo.Ternary(
 				hdr.Rrtype != dns.TypeTXT,
 				types.ListNull(types.StringType),
 				tryList(types.ListValue(types.StringType,
-					lo.Map(set.RRs, func(rr dns.RR, _ int) attr.Value {
-						return txtModelValue(rr)
+					lo.Map(set.RRs, func(rr zoneRR, _ int) attr.Value {
+						return txtModelValue(rr.RR)
+					})))),
+
+			SSHFP: lo.Ternary(
+				hdr.Rrtype != dns.TypeSSHFP,
+				types.ListNull(attributeObjectSSHFPModel.Type()),
+				tryList(types.ListValueFrom(ctx, attributeObjectSSHFPModel.Type(),
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsSSHFPModel {
+						return sshfpModelValue(rr.RR)
+					})))),
+
+			TLSA: lo.Ternary(
+				hdr.Rrtype != dns.TypeTLSA,
+				types.ListNull(attributeObjectTLSAModel.Type()),
+				tryList(types.ListValueFrom(ctx, attributeObjectTLSAModel.Type(),
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsTLSAModel {
+						return tlsaModelValue(rr.RR)
 					})))),
+
+			SMIMEA: lo.Ternary(
+				hdr.Rrtype != dns.TypeSMIMEA,
+				types.ListNull(attributeObjectTLSAModel.Type()),
+				tryList(types.ListValueFrom(ctx, attributeObjectTLSAModel.Type(),
+					lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsTLSAModel {
+						return smimeaModelValue(rr.RR)
+					})))),
+
+			Comments: tryList(types.ListValue(types.StringType,
+				lo.Map(set.RRs, func(rr zoneRR, _ int) attr.Value {
+					return commentModelValue(rr.Comment)
+				}))),
+			Metadata: set.Metadata,
+			Sources: tryList(types.ListValueFrom(ctx, attributeObjectSourceModel.Type(),
+				lo.Map(set.RRs, func(rr zoneRR, _ int) *RecordsSourceModel {
+					return sourceModelValue(rr)
+				}))),
 		}
 	})
-
-	if !resp.Diagnostics.HasError() {
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	}
+	return models, diags
 }
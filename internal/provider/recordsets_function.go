@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &RecordSetsFunction{}
+
+type RecordSetsFunction struct{}
+
+func NewRecordSetsFunction() function.Function {
+	return &RecordSetsFunction{}
+}
+
+func (f *RecordSetsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rrsets"
+}
+
+func (f *RecordSetsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a DNS zone file into RRSets: resource records grouped by name, class, and type.",
+		Description: ("Parse a DNS zone file into RRSets: resource records grouped by name, class, and type, " +
+			"with the same structure as the rrsets attribute of the zonefile_record_sets data source. " +
+			"Like the data source, this function will fail with an error if " +
+			"any RRs in an RRSet have inconsistent TTLs (per RFC 2181 section 5.2)."),
+		Parameters: functionParamsHead,
+		Return:     function.ListReturn{ElementType: attributeObjectRecordSetsItemModel.Type()},
+	}
+}
+
+func (f *RecordSetsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content, origin types.String
+	resp.Error = req.Arguments.Get(ctx, &content, &origin)
+	if resp.Error != nil {
+		return
+	}
+
+	z, err := readZone(zoneSource{
+		Origin:    origin.ValueString(),
+		Content:   content.ValueString(),
+		MaxErrors: defaultMaxErrors,
+	})
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid zone file: "+errorsDetail(err))
+		return
+	}
+
+	rrSets, _, err := groupRRs(z.RRs, ttlConflictError)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Can't group some RRs into RRSets: "+errorsDetail(err))
+		return
+	}
+
+	models, diags := recordSetsItemModels(ctx, rrSets, origin.ValueString())
+	if resp.Error = function.FuncErrorFromDiags(ctx, diags); resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, models)
+}
@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// maxIncludeDepth matches the $INCLUDE nesting limit of miekg/dns.
+const maxIncludeDepth = 7
+
+// zoneScanner parses a zone file one entry at a time, where an entry is a
+// single RR or directive that spans one line (or more, with parentheses).
+//
+// miekg/dns gives up on a zone file at its first error, which is painful for
+// large files with several mistakes. Handing it one entry at a time lets us
+// move on to the next entry after an error, at the cost of tracking the state
+// that carries across entries ($ORIGIN, $TTL, and so on) on our own.
+type zoneScanner struct {
+	includes  fs.FS
+	maxErrors int
+
+	rrs      []zoneRR
+	errs     []error
+	warnings []error
+
+	// directiveOrigin is the origin set by the first $ORIGIN directive in the
+	// main zone file, if any.
+	directiveOrigin string
+}
+
+// zoneState is the parser state that carries from one entry to the next.
+type zoneState struct {
+	file   *zoneFile
+	origin string
+	depth  int
+
+	// ttl is the default TTL for RRs that don't specify one, or nil if there is
+	// no default. Per RFC 2308 a $TTL directive sets the default, otherwise the
+	// TTL of the previous RR becomes the default as in RFC 1035.
+	ttl            *uint32
+	ttlByDirective bool
+
+	// owner is the owner name of the previous RR, which applies to RRs whose
+	// owner name is blank.
+	owner string
+}
+
+type zoneEntry struct {
+	Text string
+	Line int
+}
+
+// scan parses every entry in the file of st, and returns false if the scanner
+// has given up after reaching its error limit.
+func (s *zoneScanner) scan(st zoneState) bool {
+	for _, entry := range splitEntries(st.file.Content) {
+		fields := entryFields(entry.Text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(s.errs) >= s.maxErrors {
+			s.errs = append(s.errs, stoppedError{Errors: len(s.errs)})
+			return false
+		}
+
+		if entry.Text[0] != '$' {
+			s.parseRR(entry, &st)
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			// miekg/dns validates $ORIGIN and $TTL for us, so that errors match those
+			// of a full zone file parse.
+			if s.parse(entry, st, "") {
+				st.origin, _ = absoluteName(fields[1], st.origin)
+				if st.depth == 0 && s.directiveOrigin == "" {
+					s.directiveOrigin = st.origin
+				}
+			}
+		case "$TTL":
+			if s.parse(entry, st, "") {
+				ttl, _ := stringToTTL(fields[1])
+				st.ttl, st.ttlByDirective = &ttl, true
+			}
+		case "$INCLUDE":
+			if !s.include(entry, fields, st) {
+				return false
+			}
+		case "$GENERATE":
+			// Unlike other RRs, those generated by $GENERATE don't affect the owner
+			// name or TTL for subsequent entries.
+			n := len(s.rrs)
+			s.parse(entry, st, "")
+			for i := n; i < len(s.rrs); i++ {
+				s.rrs[i].Generated = true
+			}
+		default:
+			s.parseRR(entry, &st)
+		}
+	}
+	return true
+}
+
+// parseRR parses an entry defining a single RR, and updates the parser state
+// to reflect it.
+func (s *zoneScanner) parseRR(entry zoneEntry, st *zoneState) {
+	var prefix string
+	if entry.Text[0] == ' ' || entry.Text[0] == '\t' {
+		prefix = st.owner
+	}
+
+	n := len(s.rrs)
+	if !s.parse(entry, *st, prefix) || len(s.rrs) == n {
+		return
+	}
+
+	hdr := s.rrs[len(s.rrs)-1].RR.Header()
+	st.owner = hdr.Name
+	if !st.ttlByDirective && (st.ttl != nil || hdr.Ttl != 0) {
+		ttl := hdr.Ttl
+		st.ttl = &ttl
+	}
+}
+
+// parse runs miekg/dns over a single entry, with an optional prefix that
+// supplies the owner name for an RR that omits it.
+func (s *zoneScanner) parse(entry zoneEntry, st zoneState, prefix string) bool {
+	parser := dns.NewZoneParser(strings.NewReader(prefix+entry.Text), st.origin, st.file.Name)
+	if st.ttl != nil {
+		parser.SetDefaultTTL(*st.ttl)
+	}
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		comment := parser.Comment()
+		// Comments are free text, and might mention the annotation marker without
+		// meaning to. A malformed annotation only costs the RR its metadata.
+		metadata, token, err := parseAnnotations(comment)
+		if err != nil {
+			s.warnings = append(s.warnings, newEntryError(entry, st, err.Error(), token, strings.LastIndex(entry.Text, token)))
+			metadata = make(map[string]string)
+		}
+		s.rrs = append(s.rrs, zoneRR{
+			RR:       rr,
+			File:     st.file,
+			Line:     entry.Line,
+			Origin:   st.origin,
+			Comment:  comment,
+			Metadata: metadata,
+		})
+	}
+	if err := parser.Err(); err != nil {
+		s.errs = append(s.errs, newZoneError(err, st.file, entry.Line, len(prefix)))
+		return false
+	}
+	return true
+}
+
+// entryError records an error about a token in an entry, given the token's
+// offset in the entry's text (or -1 if it isn't there).
+func (s *zoneScanner) entryError(entry zoneEntry, st zoneState, err, token string, offset int) {
+	s.errs = append(s.errs, newEntryError(entry, st, err, token, offset))
+}
+
+// newEntryError returns an error about a token in an entry, given the token's
+// offset in the entry's text (or -1 if it isn't there).
+func newEntryError(entry zoneEntry, st zoneState, err, token string, offset int) *zoneError {
+	line, column := entry.Line, 0
+	if token != "" && offset >= 0 {
+		before := entry.Text[:offset]
+		line += strings.Count(before, "\n")
+		column = offset - strings.LastIndex(before, "\n")
+	}
+	return &zoneError{
+		File:   st.file,
+		Line:   line,
+		Column: column,
+		Token:  token,
+		Err:    err,
+	}
+}
+
+// include handles an $INCLUDE directive by scanning the included file with
+// its own origin, and returns false if the scanner has given up.
+func (s *zoneScanner) include(entry zoneEntry, fields []string, st zoneState) bool {
+	fail := func(err, token string) bool {
+		s.entryError(entry, st, err, token, strings.Index(entry.Text, token))
+		return true
+	}
+
+	if len(fields) < 2 {
+		return fail("expecting $INCLUDE value, not this...", "")
+	}
+	if len(fields) > 3 {
+		return fail("garbage after $INCLUDE", fields[3])
+	}
+	origin := st.origin
+	if len(fields) == 3 {
+		var ok bool
+		if origin, ok = absoluteName(fields[2], st.origin); !ok {
+			return fail("bad origin name", fields[2])
+		}
+	}
+	if s.includes == nil {
+		return fail("$INCLUDE directive not allowed", fields[1])
+	}
+	if st.depth >= maxIncludeDepth {
+		return fail("too deeply nested $INCLUDE", fields[1])
+	}
+
+	// Like miekg/dns, resolve relative paths against the including file.
+	includePath := fields[1]
+	if !path.IsAbs(includePath) {
+		includePath = path.Join(path.Dir(st.file.Name), includePath)
+	}
+	includePath = strings.TrimLeft(path.Clean(includePath), "/")
+
+	content, err := readIncludeFile(s.includes, includePath)
+	if err != nil {
+		var as string
+		if includePath != fields[1] {
+			as = fmt.Sprintf(" as `%s'", includePath)
+		}
+		return fail(fmt.Sprintf("failed to open `%s'%s: %v", fields[1], as, err), fields[1])
+	}
+
+	return s.scan(zoneState{
+		file:           &zoneFile{Name: includePath, Content: content},
+		origin:         origin,
+		depth:          st.depth + 1,
+		ttl:            st.ttl,
+		ttlByDirective: st.ttlByDirective,
+	})
+}
+
+func readIncludeFile(fsys fs.FS, name string) (string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	return string(b), err
+}
+
+// splitEntries splits a zone file into entries, following the rules of
+// RFC 1035 section 5.1 for comments, quoting, and parentheses.
+func splitEntries(content string) []zoneEntry {
+	var (
+		entries       []zoneEntry
+		start, line   = 0, 1
+		startLine     = 1
+		depth         int
+		quote, escape bool
+		comment       bool
+	)
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c == '\n' {
+			line++
+		}
+		ending := false
+		switch {
+		case escape:
+			escape = false
+		case comment:
+			comment = c != '\n'
+			ending = c == '\n'
+		case c == '\\':
+			escape = true
+		case quote:
+			quote = c != '"'
+		case c == '"':
+			quote = true
+		case c == ';':
+			comment = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth = max(depth-1, 0)
+		case c == '\n':
+			ending = true
+		}
+		if ending && depth == 0 {
+			entries = append(entries, zoneEntry{Text: content[start:i], Line: startLine})
+			start, startLine = i+1, line
+		}
+	}
+	if start < len(content) {
+		entries = append(entries, zoneEntry{Text: content[start:], Line: startLine})
+	}
+	return entries
+}
+
+// entryFields splits an entry into its blank-separated fields, ignoring
+// comments and parentheses.
+func entryFields(text string) []string {
+	var (
+		fields                 []string
+		field                  strings.Builder
+		quote, escape, comment bool
+	)
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case comment:
+			comment = c != '\n'
+		case escape:
+			field.WriteByte(c)
+			escape = false
+		case c == '\\':
+			field.WriteByte(c)
+			escape = true
+		case quote:
+			field.WriteByte(c)
+			quote = c != '"'
+		case c == '"':
+			field.WriteByte(c)
+			quote = true
+		case c == ';':
+			flush()
+			comment = true
+		case c == ' ', c == '\t', c == '\r', c == '\n', c == '(', c == ')':
+			flush()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	flush()
+	return fields
+}
+
+// absoluteName qualifies a name from a zone file relative to origin, like the
+// unexported function of the same purpose in miekg/dns.
+func absoluteName(name, origin string) (string, bool) {
+	if name == "@" {
+		return origin, origin != ""
+	}
+	if _, ok := dns.IsDomainName(name); !ok {
+		return "", false
+	}
+	if dns.IsFqdn(name) {
+		return name, true
+	}
+	if origin == "" {
+		return "", false
+	}
+	if origin == "." {
+		return name + origin, true
+	}
+	return name + "." + origin, true
+}
+
+// stringToTTL parses a TTL with optional BIND-style unit suffixes, like the
+// unexported function of the same purpose in miekg/dns.
+func stringToTTL(token string) (uint32, bool) {
+	var s, i uint32
+	for _, c := range token {
+		switch c {
+		case 's', 'S':
+			s += i
+			i = 0
+		case 'm', 'M':
+			s += i * 60
+			i = 0
+		case 'h', 'H':
+			s += i * 60 * 60
+			i = 0
+		case 'd', 'D':
+			s += i * 60 * 60 * 24
+			i = 0
+		case 'w', 'W':
+			s += i * 60 * 60 * 24 * 7
+			i = 0
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			i *= 10
+			i += uint32(c) - '0'
+		default:
+			return 0, false
+		}
+	}
+	return s + i, true
+}
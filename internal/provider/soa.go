@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+	"github.com/samber/lo"
+)
+
+var _ datasource.DataSourceWithConfigure = &SOADataSource{}
+var _ datasource.DataSourceWithValidateConfig = &SOADataSource{}
+
+type SOADataSource struct {
+	includeRoot string
+}
+
+func NewSOADataSource() datasource.DataSource {
+	return &SOADataSource{}
+}
+
+func (d *SOADataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_soa"
+}
+
+func (d *SOADataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read a DNS zone file and return the parsed fields of its SOA record.",
+		Attributes:  schemaSOAModel,
+	}
+}
+
+func (d *SOADataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.includeRoot = configureIncludeRoot(req, resp)
+}
+
+func (d *SOADataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	validateZoneSourceConfig(ctx, req.Config, resp)
+}
+
+func (d *SOADataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SOAModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	src, err := newZoneSource(data.Origin.ValueString(), data.Content.ValueString(), data.Path.ValueString(), data.Includes, d.includeRoot)
+	if err != nil {
+		resp.Diagnostics.AddError("Can't read zone file", err.Error())
+		return
+	}
+	src.MaxErrors = maxErrorsValue(data.MaxErrors)
+	z, err := readZone(src)
+	if err != nil {
+		resp.Diagnostics.Append(zoneErrorDiagnostics("Invalid zone file", err, zoneSourceAttribute(data.Path))...)
+		return
+	}
+	resp.Diagnostics.Append(zoneWarningDiagnostics("Malformed annotation", z.Warnings, zoneSourceAttribute(data.Path))...)
+
+	soas := lo.Filter(z.RRs, func(rr zoneRR, _ int) bool { return rr.RR.Header().Rrtype == dns.TypeSOA })
+	switch {
+	case len(soas) == 0:
+		resp.Diagnostics.AddAttributeError(zoneSourceAttribute(data.Path),
+			"Missing SOA record",
+			"The zone file has no SOA record.")
+		return
+	case len(soas) > 1:
+		resp.Diagnostics.Append(zoneErrorDiagnostics("Multiple SOA records", &zoneError{
+			File: soas[1].File,
+			Line: soas[1].Line,
+			Err:  fmt.Sprintf("zone has more than one SOA record (first at %s)", soas[0].position(soas[1])),
+		}, zoneSourceAttribute(data.Path))...)
+		return
+	}
+
+	rr := soas[0]
+	hdr := rr.RR.Header()
+	soa := soaModelValue(rr.RR)
+	data.FQDN = types.StringValue(hdr.Name)
+	data.TTL = types.Int64Value(int64(hdr.Ttl))
+	data.MName, data.RName, data.RNameEmail = soa.MName, soa.RName, soa.RNameEmail
+	data.Serial, data.Refresh, data.Retry, data.Expire, data.Minimum = soa.Serial, soa.Refresh, soa.Retry, soa.Expire, soa.Minimum
+	data.Source = sourceModelValue(rr)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
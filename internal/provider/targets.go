@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/publicsuffix"
+)
+
+// rdataTargets returns the domain names in the RDATA of an RR that refer to
+// other hosts, which zone files often write as relative names by mistake.
+func rdataTargets(rr dns.RR) []string {
+	switch rr := rr.(type) {
+	case *dns.CNAME:
+		return []string{rr.Target}
+	case *dns.DNAME:
+		return []string{rr.Target}
+	case *dns.MX:
+		return []string{rr.Mx}
+	case *dns.NS:
+		return []string{rr.Ns}
+	case *dns.PTR:
+		return []string{rr.Ptr}
+	case *dns.SRV:
+		return []string{rr.Target}
+	case *dns.SVCB:
+		return []string{rr.Target}
+	case *dns.HTTPS:
+		return []string{rr.Target}
+	case *dns.SOA:
+		return []string{rr.Ns}
+	}
+	return nil
+}
+
+// checkTrailingDots returns an error for each RDATA target in rrs that looks
+// like it was meant to be fully qualified, but was written without a trailing
+// dot so that the origin was appended to it:
+//
+//	www IN CNAME web.example.com ; web.example.com.example.com.
+//
+// This is only a heuristic, so these errors are normally warnings.
+func checkTrailingDots(rrs []zoneRR) []error {
+	var errs []error
+	for _, rr := range rrs {
+		if rr.Origin == "" || rr.Origin == "." {
+			continue
+		}
+		for _, target := range rdataTargets(rr.RR) {
+			meant, ok := missingTrailingDot(target, rr.Origin)
+			if !ok {
+				continue
+			}
+			hdr := rr.RR.Header()
+			errs = append(errs, &zoneError{
+				File: rr.File,
+				Line: rr.Line,
+				Err: fmt.Sprintf(
+					"%s %s %s record has target %s, which may be missing a trailing dot (did you mean %s?)",
+					dns.ClassToString[hdr.Class],
+					dns.TypeToString[hdr.Rrtype],
+					hdr.Name,
+					target,
+					meant,
+				),
+			})
+		}
+	}
+	return errs
+}
+
+// missingTrailingDot decides whether target, a name under origin, was probably
+// written as a fully qualified name without a trailing dot, and if so returns
+// the name that was meant. That's the case if the part of target before origin
+// either repeats origin, or has more than one label and ends in the same
+// top-level label as origin (like "web.example.net" under "example.com.").
+//
+// In reverse zones, where names under the origin are addresses rather than
+// hosts, it's also the case if the part before origin ends in an ICANN TLD in
+// the public suffix list (like "host.example.com" under
+// "2.0.192.in-addr.arpa."). Elsewhere, names like "web.dev" are too likely to
+// be meant as relative names.
+func missingTrailingDot(target, origin string) (string, bool) {
+	if len(target) <= len(origin) || !dns.IsSubDomain(origin, target) {
+		return "", false
+	}
+	relative := strings.TrimSuffix(target[:len(target)-len(origin)], ".")
+	meant := dns.Fqdn(relative)
+	if dns.IsSubDomain(origin, meant) {
+		return meant, true
+	}
+
+	labels, originLabels := dns.SplitDomainName(relative), dns.SplitDomainName(origin)
+	if len(labels) < 2 {
+		return "", false
+	}
+	tld := labels[len(labels)-1]
+	if strings.EqualFold(tld, originLabels[len(originLabels)-1]) {
+		return meant, true
+	}
+	if !dns.IsSubDomain("in-addr.arpa.", origin) && !dns.IsSubDomain("ip6.arpa.", origin) {
+		return "", false
+	}
+	_, icann := publicsuffix.PublicSuffix(strings.ToLower(tld))
+	return meant, icann
+}